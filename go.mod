@@ -1,6 +1,6 @@
 module github.com/janmbaco/go-redux
 
-go 1.15
+go 1.18
 
 require (
 	github.com/janmbaco/go-infrastructure v1.2.0
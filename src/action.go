@@ -1,54 +1,165 @@
 package redux
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+
+	"github.com/janmbaco/go-infrastructure/errors/errorschecker"
 )
 
 type Action interface {
 	With(interface{}) Action
+	WithE(interface{}) (Action, error)
+	WithMeta(key string, value interface{}) Action
+	WithContext(ctx context.Context) Action
 	GetPayload() reflect.Value
+	GetMeta(key string) interface{}
+	GetContext() context.Context
 	SetPayloadType(reflect.Type)
+	GetPayloadType() reflect.Type
 	GetType() string
+	GetOrigin() Action
 }
 
 type action struct {
-	payload   reflect.Value
-	typ       reflect.Type
-	payloaded bool
-	name      string
+	typ  reflect.Type
+	name string
 }
 
 func (action *action) With(payload interface{}) Action {
-
-	if action.typ == nil {
-		panic("no payload can be assigned to this action!")
+	result, err := action.WithE(payload)
+	if err != nil {
+		panic(err)
 	}
+	return result
+}
 
-	if reflect.TypeOf(payload) != action.typ {
-		panic(fmt.Sprintf("The type of payload must be '%v'", action.typ.String()))
+func (action *action) WithE(payload interface{}) (Action, error) {
+	if err := action.checkPayload(payload); err != nil {
+		return nil, err
 	}
+	return &dispatchedAction{origin: action, payload: reflect.ValueOf(payload), payloaded: true}, nil
+}
 
-	action.payload = reflect.ValueOf(payload)
-	action.payloaded = true
-	return action
+func (action *action) WithMeta(key string, value interface{}) Action {
+	return &dispatchedAction{origin: action, meta: map[string]interface{}{key: value}}
+}
+
+func (action *action) WithContext(ctx context.Context) Action {
+	errorschecker.CheckNilParameter(map[string]interface{}{"ctx": ctx})
+	return &dispatchedAction{origin: action, ctx: ctx}
 }
 
 func (action *action) GetPayload() reflect.Value {
-	var result reflect.Value
-	if action.payloaded {
-		result = action.payload
-		action.payloaded = false
-	} else {
-		result = reflect.Zero(action.typ)
+	if action.typ == nil {
+		return reflect.Value{}
 	}
-	return result
+	return reflect.Zero(action.typ)
+}
+
+func (action *action) GetMeta(key string) interface{} {
+	return nil
+}
+
+func (action *action) GetContext() context.Context {
+	return context.Background()
 }
 
 func (action *action) SetPayloadType(typ reflect.Type) {
 	action.typ = typ
 }
 
+func (action *action) GetPayloadType() reflect.Type {
+	return action.typ
+}
+
 func (action *action) GetType() string {
 	return action.name
 }
+
+func (action *action) GetOrigin() Action {
+	return action
+}
+
+func (action *action) checkPayload(payload interface{}) error {
+	if action.typ == nil {
+		return newStoreError(PayloadTypeError, "no payload can be assigned to this action!")
+	}
+
+	if reflect.TypeOf(payload) != action.typ {
+		return newStoreError(PayloadTypeError, fmt.Sprintf("The type of payload must be '%v'", action.typ.String()))
+	}
+	return nil
+}
+
+type dispatchedAction struct {
+	origin    *action
+	payload   reflect.Value
+	payloaded bool
+	meta      map[string]interface{}
+	ctx       context.Context
+}
+
+func (d *dispatchedAction) With(payload interface{}) Action {
+	result, err := d.WithE(payload)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+func (d *dispatchedAction) WithE(payload interface{}) (Action, error) {
+	if err := d.origin.checkPayload(payload); err != nil {
+		return nil, err
+	}
+	return &dispatchedAction{origin: d.origin, payload: reflect.ValueOf(payload), payloaded: true, meta: d.meta, ctx: d.ctx}, nil
+}
+
+func (d *dispatchedAction) WithMeta(key string, value interface{}) Action {
+	meta := make(map[string]interface{}, len(d.meta)+1)
+	for k, v := range d.meta {
+		meta[k] = v
+	}
+	meta[key] = value
+	return &dispatchedAction{origin: d.origin, payload: d.payload, payloaded: d.payloaded, meta: meta, ctx: d.ctx}
+}
+
+func (d *dispatchedAction) WithContext(ctx context.Context) Action {
+	errorschecker.CheckNilParameter(map[string]interface{}{"ctx": ctx})
+	return &dispatchedAction{origin: d.origin, payload: d.payload, payloaded: d.payloaded, meta: d.meta, ctx: ctx}
+}
+
+func (d *dispatchedAction) GetPayload() reflect.Value {
+	if !d.payloaded {
+		return d.origin.GetPayload()
+	}
+	return d.payload
+}
+
+func (d *dispatchedAction) GetMeta(key string) interface{} {
+	return d.meta[key]
+}
+
+func (d *dispatchedAction) GetContext() context.Context {
+	if d.ctx == nil {
+		return d.origin.GetContext()
+	}
+	return d.ctx
+}
+
+func (d *dispatchedAction) SetPayloadType(reflect.Type) {
+	panic("The payload type can only be set on the action declared in the ActionsObject!")
+}
+
+func (d *dispatchedAction) GetPayloadType() reflect.Type {
+	return d.origin.typ
+}
+
+func (d *dispatchedAction) GetType() string {
+	return d.origin.name
+}
+
+func (d *dispatchedAction) GetOrigin() Action {
+	return d.origin
+}
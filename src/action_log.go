@@ -0,0 +1,286 @@
+package redux
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/janmbaco/go-infrastructure/errors/errorschecker"
+	"github.com/janmbaco/go-redux/src/jsonpatch"
+)
+
+type ActionLogEntry struct {
+	Seq       uint64
+	Selector  string
+	Action    string
+	Payload   json.RawMessage
+	Timestamp time.Time
+}
+
+var ReplayAction Action = &action{name: "@@REPLAY"}
+
+type ActionLog interface {
+	Append(entries ...ActionLogEntry) error
+	Entries(fromSeq uint64) ([]ActionLogEntry, error)
+	LastSeq() (uint64, error)
+}
+
+type memoryActionLog struct {
+	mutex   sync.RWMutex
+	entries []ActionLogEntry
+}
+
+func NewMemoryActionLog() ActionLog {
+	return &memoryActionLog{entries: make([]ActionLogEntry, 0)}
+}
+
+func (l *memoryActionLog) Append(entries ...ActionLogEntry) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.entries = append(l.entries, entries...)
+	return nil
+}
+
+func (l *memoryActionLog) Entries(fromSeq uint64) ([]ActionLogEntry, error) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	result := make([]ActionLogEntry, 0)
+	for _, entry := range l.entries {
+		if entry.Seq >= fromSeq {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+func (l *memoryActionLog) LastSeq() (uint64, error) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	if len(l.entries) == 0 {
+		return 0, nil
+	}
+	return l.entries[len(l.entries)-1].Seq, nil
+}
+
+func (s *store) SetActionLog(log ActionLog) {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if log != nil {
+		seq, err := log.LastSeq()
+		if err != nil {
+			panic(newStoreErrorFrom(ActionLogError, err))
+		}
+		if seq > s.seq {
+			s.seq = seq
+		}
+	}
+	s.actionLog = log
+}
+
+func (s *store) GetActionLog() ActionLog {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.actionLog
+}
+
+func (s *store) Replay(log ActionLog, fromSeq uint64) {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	errorschecker.CheckNilParameter(map[string]interface{}{"log": log})
+	entries, err := log.Entries(fromSeq)
+	if err != nil {
+		panic(newStoreErrorFrom(ActionLogError, err))
+	}
+
+	results := make([]DispatchResult, 0, len(entries)+1)
+	defer s.flush()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	actions := make([]Action, 0, len(entries))
+	for _, entry := range entries {
+		actions = append(actions, s.decodeReplayedAction(entry))
+	}
+	defer func() {
+		s.enqueue(nil, false, results...)
+	}()
+	reset := DispatchResult{Action: ReplayAction, Seq: s.seq, Slices: make([]SliceResult, 0, len(s.params))}
+	for selector, param := range s.params {
+		stateManagement := s.stateManagements[selector]
+		slice := SliceResult{Selector: selector, PrevState: stateManagement.GetState()}
+		if slice.Changed = stateManagement.Update(getCloner(param)(param.GetInitialState())); slice.Changed {
+			s.versions[selector]++
+		}
+		if param.GetHistory() != nil {
+			s.histories[selector] = newHistory(param.GetHistory())
+		}
+		slice.NextState = stateManagement.GetState()
+		reset.Slices = append(reset.Slices, slice)
+	}
+	reset.Slices = append(reset.Slices, s.recompute(changedSelectors(reset.Slices))...)
+	results = append(results, reset)
+	for i, entry := range entries {
+		seq := s.seq
+		result := s.replayAction(entry.Selector, actions[i], s.selectorsByAction[actions[i].GetOrigin()])
+		result.Seq = entry.Seq
+		results = append(results, result)
+		s.seq = seq
+		if entry.Seq > s.seq {
+			s.seq = entry.Seq
+		}
+	}
+}
+
+func (s *store) decodeReplayedAction(entry ActionLogEntry) Action {
+	action := s.decodeAction(entry)
+	if action.GetOrigin() != PatchAction && len(s.selectorsByAction[action.GetOrigin()]) == 0 {
+		panic(newStoreError(ActionLogError, fmt.Sprintf("There are not any Reducers that execute the action '%v'!", entry.Action)))
+	}
+	return action
+}
+
+func (s *store) Recover(log ActionLog) {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	errorschecker.CheckNilParameter(map[string]interface{}{"log": log})
+	s.mutex.RLock()
+	fromSeq := s.seq
+	for selector := range s.params {
+		if s.snapshotSeqs[selector] < fromSeq {
+			fromSeq = s.snapshotSeqs[selector]
+		}
+	}
+	s.mutex.RUnlock()
+	entries, err := log.Entries(fromSeq + 1)
+	if err != nil {
+		panic(newStoreErrorFrom(ActionLogError, err))
+	}
+
+	results := make([]DispatchResult, 0, len(entries))
+	defer s.flush()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	defer func() {
+		s.enqueue(nil, false, results...)
+	}()
+	for _, entry := range entries {
+		action := s.decodeAction(entry)
+		candidates := s.selectorsByAction[action.GetOrigin()]
+		if action.GetOrigin() == PatchAction {
+			candidates = []string{entry.Selector}
+		}
+		selectors := make([]string, 0)
+		for _, selector := range candidates {
+			if s.snapshotSeqs[selector] < entry.Seq {
+				selectors = append(selectors, selector)
+				s.snapshotSeqs[selector] = entry.Seq
+			}
+		}
+		seq := s.seq
+		if len(selectors) > 0 {
+			result := s.replayAction(entry.Selector, action, selectors)
+			result.Seq = entry.Seq
+			results = append(results, result)
+		}
+		s.seq = seq
+		if entry.Seq > s.seq {
+			s.seq = entry.Seq
+		}
+	}
+}
+
+func (s *store) replayAction(selector string, action Action, selectors []string) DispatchResult {
+	if action.GetOrigin() == PatchAction {
+		return s.applyPatch(selector, action.GetPayload().Interface().(jsonpatch.Patch), false)
+	}
+	return s.applyAction(action, selectors, false)
+}
+
+func (s *store) DecodeAction(entry ActionLogEntry) (action Action, err error) {
+	err = s.try(func() {
+		s.mutex.RLock()
+		defer s.mutex.RUnlock()
+		action = s.decodeAction(entry)
+	})
+	return action, err
+}
+
+func (s *store) appendToLog(actions ...Action) {
+	if s.actionLog == nil || len(actions) == 0 {
+		return
+	}
+	entries := make([]ActionLogEntry, 0, len(actions))
+	for i, action := range actions {
+		entries = append(entries, s.newLogEntry(s.getOwnerOf(action), action, s.seq+uint64(i)+1))
+	}
+	s.writeToLog(entries...)
+}
+
+func (s *store) newLogEntry(selector string, action Action, seq uint64) ActionLogEntry {
+	entry := ActionLogEntry{Seq: seq, Selector: selector, Action: action.GetType(), Timestamp: time.Now()}
+	if action.GetPayloadType() != nil {
+		payload, err := json.Marshal(action.GetPayload().Interface())
+		if err != nil {
+			panic(newStoreErrorFrom(ActionLogError, err))
+		}
+		entry.Payload = payload
+	}
+	return entry
+}
+
+func (s *store) writeToLog(entries ...ActionLogEntry) {
+	if s.actionLog == nil {
+		return
+	}
+	if err := s.actionLog.Append(entries...); err != nil {
+		panic(newStoreErrorFrom(ActionLogError, err))
+	}
+}
+
+func (s *store) decodeAction(entry ActionLogEntry) Action {
+	action := s.getActionByName(entry.Selector, entry.Action)
+	if action == nil {
+		panic(newStoreError(ActionLogError, fmt.Sprintf("There is not any action '%v' with the selector: '%v'!", entry.Action, entry.Selector)))
+	}
+	if action.GetPayloadType() == nil || len(entry.Payload) == 0 {
+		return action
+	}
+	payload := reflect.New(action.GetPayloadType())
+	if err := json.Unmarshal(entry.Payload, payload.Interface()); err != nil {
+		panic(newStoreErrorFrom(ActionLogError, err))
+	}
+	return action.With(payload.Elem().Interface())
+}
+
+func (s *store) getOwnerOf(action Action) string {
+	selectors := s.selectorsByAction[action.GetOrigin()]
+	for _, selector := range selectors {
+		if s.actionsObject[selector].Contains(action) || s.isHistoryAction(selector, action) {
+			return selector
+		}
+	}
+	return selectors[0]
+}
+
+func (s *store) getActionByName(selector string, name string) Action {
+	param, exists := s.params[selector]
+	if !exists {
+		return nil
+	}
+	if name == PatchAction.GetType() {
+		return PatchAction
+	}
+	if param.GetActionsObject().ContainsByName(name) {
+		return param.GetActionsObject().GetActionByName(name)
+	}
+	actions := param.GetExtraActions()
+	if history, exists := s.histories[selector]; exists {
+		actions = append(actions, history.getActions()...)
+	}
+	for _, action := range actions {
+		if action.GetType() == name {
+			return action.GetOrigin()
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package redux_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/janmbaco/go-redux/src"
+)
+
+func TestReplayNotifiesTheResetWithTheReplayAction(t *testing.T) {
+	store, actions := newCounterStore(t)
+	log := redux.NewMemoryActionLog()
+	store.SetActionLog(log)
+	store.Dispatch(actions.Increment.With(2))
+	store.Dispatch(actions.Increment.With(3))
+
+	changes := make([]redux.Change, 0)
+	onChange := func(change redux.Change) {
+		changes = append(changes, change)
+	}
+	store.SubscribeChanges(&onChange)
+	store.Replay(log, 2)
+
+	if len(changes) != 1 {
+		t.Fatalf("%v changes were notified, want 1", len(changes))
+	}
+	if changes[0].Action == nil || changes[0].Action.GetType() != actions.Increment.GetType() {
+		t.Fatalf("the action of the change is %v, want the last replayed action", changes[0].Action)
+	}
+	if state := store.GetStateOf("counter"); state != 3 {
+		t.Fatalf("the state is %v, want 3", state)
+	}
+
+	changes = changes[:0]
+	store.Replay(redux.NewMemoryActionLog(), 0)
+	if len(changes) != 1 || changes[0].Action != redux.ReplayAction {
+		t.Fatalf("the changes are %v, want one change with the ReplayAction", changes)
+	}
+	if changes[0].Prev != 3 || changes[0].Next != 0 {
+		t.Fatalf("the change is %v -> %v, want 3 -> 0", changes[0].Prev, changes[0].Next)
+	}
+}
+
+func TestReplayNeverLowersTheSeq(t *testing.T) {
+	store, actions := newCounterStore(t)
+	log := redux.NewMemoryActionLog()
+	store.SetActionLog(log)
+	store.Dispatch(actions.Increment.With(1))
+	store.Dispatch(actions.Increment.With(1))
+	store.Dispatch(actions.Increment.With(1))
+	entries, _ := log.Entries(0)
+	short := redux.NewMemoryActionLog()
+	short.Append(entries[0])
+
+	store.Replay(short, 0)
+	store.Dispatch(actions.Increment.With(1))
+
+	entries, _ = log.Entries(0)
+	seqs := make(map[uint64]bool)
+	for _, entry := range entries {
+		if seqs[entry.Seq] {
+			t.Fatalf("the seq %v is duplicated in the log", entry.Seq)
+		}
+		seqs[entry.Seq] = true
+	}
+	if last := entries[len(entries)-1].Seq; last != 4 {
+		t.Fatalf("the seq of the action dispatched after the replay is %v, want 4", last)
+	}
+	if state := store.GetStateOf("counter"); state != 2 {
+		t.Fatalf("the state is %v, want 2", state)
+	}
+}
+
+func TestReplayDoesNotResetTheStateWhenAnEntryCanNotBeDecoded(t *testing.T) {
+	store, actions := newCounterStore(t)
+	store.Dispatch(actions.Increment.With(3))
+	log := redux.NewMemoryActionLog()
+	log.Append(
+		redux.ActionLogEntry{Seq: 1, Selector: "counter", Action: "Increment", Payload: []byte("1")},
+		redux.ActionLogEntry{Seq: 2, Selector: "counter", Action: "Unknown"},
+	)
+	notified := false
+	onChange := func(interface{}) {
+		notified = true
+	}
+	store.SubscribeTo("counter", &onChange)
+
+	if err := catch(func() { store.Replay(log, 0) }); !errors.Is(err, redux.ActionLogError) {
+		t.Fatalf("the error is %v, want an ActionLogError", err)
+	}
+	if state := store.GetStateOf("counter"); state != 3 {
+		t.Fatalf("the state is %v, want 3", state)
+	}
+	if notified {
+		t.Fatal("the subscribers were notified of a failed replay")
+	}
+}
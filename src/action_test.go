@@ -0,0 +1,71 @@
+package redux_test
+
+import (
+	"context"
+	"testing"
+)
+
+type requestKey struct{}
+
+func TestWithDoesNotModifyTheAction(t *testing.T) {
+	_, actions := newCounterStore(t)
+
+	first := actions.Increment.With(1)
+	second := first.With(2)
+
+	if payload := actions.Increment.GetPayload().Interface(); payload != 0 {
+		t.Fatalf("the declared action has the payload %v, want 0", payload)
+	}
+	if payload := first.GetPayload().Interface(); payload != 1 {
+		t.Fatalf("the first action has the payload %v, want 1", payload)
+	}
+	if payload := first.GetPayload().Interface(); payload != 1 {
+		t.Fatalf("reading the payload twice returned %v, want 1", payload)
+	}
+	if payload := second.GetPayload().Interface(); payload != 2 {
+		t.Fatalf("the second action has the payload %v, want 2", payload)
+	}
+	if first.GetOrigin() != actions.Increment || second.GetOrigin() != actions.Increment {
+		t.Fatal("the actions do not keep their origin")
+	}
+}
+
+func TestWithMetaDoesNotModifyTheAction(t *testing.T) {
+	_, actions := newCounterStore(t)
+
+	first := actions.Increment.With(1).WithMeta("requestId", "1")
+	second := first.WithMeta("requestId", "2").WithMeta("user", "jan")
+
+	if meta := actions.Increment.GetMeta("requestId"); meta != nil {
+		t.Fatalf("the declared action has the meta %v, want nil", meta)
+	}
+	if meta := first.GetMeta("requestId"); meta != "1" {
+		t.Fatalf("the first action has the meta %v, want 1", meta)
+	}
+	if meta := first.GetMeta("user"); meta != nil {
+		t.Fatalf("the first action has the meta %v, want nil", meta)
+	}
+	if meta := second.GetMeta("requestId"); meta != "2" {
+		t.Fatalf("the second action has the meta %v, want 2", meta)
+	}
+	if payload := second.GetPayload().Interface(); payload != 1 {
+		t.Fatalf("the second action has the payload %v, want 1", payload)
+	}
+}
+
+func TestWithContextDoesNotModifyTheAction(t *testing.T) {
+	_, actions := newCounterStore(t)
+	ctx := context.WithValue(context.Background(), requestKey{}, "42")
+
+	action := actions.Increment.With(1).WithContext(ctx)
+
+	if actions.Increment.GetContext().Value(requestKey{}) != nil {
+		t.Fatal("the declared action has the context of the dispatched action")
+	}
+	if action.GetContext().Value(requestKey{}) != "42" {
+		t.Fatal("the action does not carry its context")
+	}
+	if payload := action.GetPayload().Interface(); payload != 1 {
+		t.Fatalf("the action has the payload %v, want 1", payload)
+	}
+}
@@ -44,7 +44,7 @@ func (ao *actionsObject) GetActions() []Action {
 }
 
 func (ao *actionsObject) Contains(action Action) bool {
-	_, ok := ao.nameByAction[action]
+	_, ok := ao.nameByAction[action.GetOrigin()]
 	return ok
 }
 
@@ -62,7 +62,7 @@ func (ao *actionsObject) GetActionByName(name string) Action {
 }
 
 func (ao *actionsObject) GetNameByAction(action Action) string {
-	return ao.nameByAction[action]
+	return ao.nameByAction[action.GetOrigin()]
 }
 
 func getActionsIn(catcher errors.ErrorCatcher, object interface{}) []Action {
@@ -75,13 +75,13 @@ func getActionsIn(catcher errors.ErrorCatcher, object interface{}) []Action {
 		if rt.Field(i).Type.Implements(actionType) {
 			if rv.Field(i).IsNil() {
 				catcher.TryCatchError(func() {
-					rv.Field(i).Set(reflect.ValueOf(&action{name: rt.Field(i).Name}))
-					result = append(result, rv.Field(i).Elem().Interface().(Action))
+					rv.Field(i).Set(newActionValue(rt.Field(i).Type, rt.Field(i).Name))
+					result = append(result, rv.Field(i).Interface().(Action).GetOrigin())
 				}, func(err error) {
 					panicMessage = append(panicMessage, fmt.Sprintf("The custom action '%v' of type '%v' in '%v' can't be nil!", rt.Field(i).Name, rt.Field(i).Type.String(), rt.String()))
 				})
 			} else {
-				result = append(result, rv.Field(i).Interface().(Action))
+				result = append(result, rv.Field(i).Interface().(Action).GetOrigin())
 			}
 		}
 	}
@@ -93,3 +93,15 @@ func getActionsIn(catcher errors.ErrorCatcher, object interface{}) []Action {
 	}
 	return result
 }
+
+func newActionValue(typ reflect.Type, name string) reflect.Value {
+	actionValue := reflect.ValueOf(&action{name: name})
+	if typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Struct && typ.Elem().NumField() > 0 {
+		if field := typ.Elem().Field(0); field.Anonymous && field.Type == reflect.TypeOf((*Action)(nil)).Elem() {
+			wrapper := reflect.New(typ.Elem())
+			wrapper.Elem().Field(0).Set(actionValue)
+			return wrapper
+		}
+	}
+	return actionValue
+}
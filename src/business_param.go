@@ -5,6 +5,12 @@ type BusinessParam interface {
 	GetReducer() Reducer
 	GetInitialState() interface{}
 	GetSelector() string
+	GetExtraActions() []Action
+	GetHistory() *HistoryParam
+	IsPersistent() bool
+	GetEqualityFunc() EqualityFunc
+	GetCloner() Cloner
+	IsReadOnlyState() bool
 }
 
 type businessParam struct {
@@ -12,6 +18,12 @@ type businessParam struct {
 	reducer      Reducer
 	initialState interface{}
 	selector     string
+	extraActions []Action
+	history      *HistoryParam
+	persistent   bool
+	equality     EqualityFunc
+	cloner       Cloner
+	readOnly     bool
 }
 
 func (b businessParam) GetActionsObject() ActionsObject {
@@ -30,6 +42,30 @@ func (b businessParam) GetSelector() string {
 	return b.selector
 }
 
-func NewBusinessParam(initialState interface{}, reducer Reducer, actionObject ActionsObject, selector string) BusinessParam {
-	return &businessParam{actionObject: actionObject, reducer: reducer, initialState: initialState, selector: selector}
+func (b businessParam) GetExtraActions() []Action {
+	return b.extraActions
+}
+
+func (b businessParam) GetHistory() *HistoryParam {
+	return b.history
+}
+
+func (b businessParam) IsPersistent() bool {
+	return b.persistent
+}
+
+func (b businessParam) GetEqualityFunc() EqualityFunc {
+	return b.equality
+}
+
+func (b businessParam) GetCloner() Cloner {
+	return b.cloner
+}
+
+func (b businessParam) IsReadOnlyState() bool {
+	return b.readOnly
+}
+
+func NewBusinessParam(initialState interface{}, reducer Reducer, actionObject ActionsObject, selector string, extraActions []Action, history *HistoryParam, persistent bool, equality EqualityFunc, cloner Cloner, readOnly bool) BusinessParam {
+	return &businessParam{actionObject: actionObject, reducer: reducer, initialState: initialState, selector: selector, extraActions: extraActions, history: history, persistent: persistent, equality: equality, cloner: cloner, readOnly: readOnly}
 }
@@ -1,42 +1,66 @@
 package redux
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/janmbaco/go-infrastructure/errors/errorschecker"
 	"github.com/janmbaco/go-infrastructure/logs"
 )
 
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
 type BusinesParamBuilder interface {
 	SetInitialState(interface{}) BusinesParamBuilder
 	SetActions(interface{}) BusinesParamBuilder
 	SetSelector(selector string) BusinesParamBuilder
 	On(action Action, function interface{}) BusinesParamBuilder
+	OnExtra(action Action, function interface{}) BusinesParamBuilder
 	SetActionsLogicByObject(object interface{}) BusinesParamBuilder
+	WithHistory(limit int, actions *HistoryActions) BusinesParamBuilder
+	FilterHistory(actions ...Action) BusinesParamBuilder
+	GroupHistory(window time.Duration) BusinesParamBuilder
+	SetPersistent(persistent bool) BusinesParamBuilder
+	SetEqualityFunc(equality EqualityFunc) BusinesParamBuilder
+	SetCloner(cloner Cloner) BusinesParamBuilder
+	SetReadOnlyState(readOnly bool) BusinesParamBuilder
 	GetBusinessParam() BusinessParam
+	Build() (BusinessParam, error)
+	NewBuilder() BusinesParamBuilder
 }
 
 type businessParamBuilder struct {
-	logger        logs.Logger
-	initialState  interface{}
-	selector      string
+	logger               logs.Logger
+	initialState         interface{}
+	selector             string
 	businessParamFactory BusinessParamFactory
 	actionsObjectFactory ActionsObjectFactory
-	actionsObject ActionsObject
-	blf           map[Action]reflect.Value // business logic funcionality
+	actionsObject        ActionsObject
+	extraActions         []Action
+	history              *HistoryParam
+	persistent           bool
+	equality             EqualityFunc
+	cloner               Cloner
+	readOnlyState        bool
+	blf                  map[Action]reflect.Value // business logic funcionality
 }
 type redueActions struct {
 	blf map[Action]reflect.Value
 }
 
 func NewBusinessParamBuilder(logger logs.Logger, aactionsObjectFactory ActionsObjectFactory, businessParamFactory BusinessParamFactory) BusinesParamBuilder {
-	errorschecker.CheckNilParameter(map[string]interface{}{"logger": logger, "aactionsObjectFactory": aactionsObjectFactory, "businessParamFactory":businessParamFactory})
+	errorschecker.CheckNilParameter(map[string]interface{}{"logger": logger, "aactionsObjectFactory": aactionsObjectFactory, "businessParamFactory": businessParamFactory})
 	return &businessParamBuilder{blf: make(map[Action]reflect.Value), logger: logger, actionsObjectFactory: aactionsObjectFactory, businessParamFactory: businessParamFactory}
 }
 
+func (builder *businessParamBuilder) NewBuilder() BusinesParamBuilder {
+	return NewBusinessParamBuilder(builder.logger, builder.actionsObjectFactory, builder.businessParamFactory)
+}
+
 func (builder *businessParamBuilder) SetInitialState(initialState interface{}) BusinesParamBuilder {
 	builder.initialState = initialState
 	return builder
@@ -64,26 +88,76 @@ func (builder *businessParamBuilder) On(action Action, function interface{}) Bus
 		panic("This action doesn`t belong to this BusinesObject!")
 	}
 
+	action = action.GetOrigin()
+	functionType := builder.checkFunction(action, function)
+
+	if payloadType := getPayloadType(functionType, 0); payloadType != nil {
+		action.SetPayloadType(payloadType)
+	}
+
+	builder.blf[action] = reflect.ValueOf(function)
+	return builder
+}
+
+func (builder *businessParamBuilder) OnExtra(action Action, function interface{}) BusinesParamBuilder {
+	errorschecker.CheckNilParameter(map[string]interface{}{"action": action, "function": function})
+
+	if builder.actionsObject != nil && builder.actionsObject.Contains(action) {
+		panic("This action belongs to this BusinesObject, use On instead!")
+	}
+
+	action = action.GetOrigin()
+	functionType := builder.checkFunction(action, function)
+
+	if payloadType := getPayloadType(functionType, 0); payloadType != nil {
+		if action.GetPayloadType() == nil {
+			action.SetPayloadType(payloadType)
+		} else if action.GetPayloadType() != payloadType {
+			panic(fmt.Errorf("the payload of the action `%v` must be `%v`", action.GetType(), action.GetPayloadType().String()))
+		}
+	}
+
+	builder.blf[action] = reflect.ValueOf(function)
+	builder.extraActions = append(builder.extraActions, action)
+	return builder
+}
+
+func (builder *businessParamBuilder) checkFunction(action Action, function interface{}) reflect.Type {
 	if _, exists := builder.blf[action]; exists {
 		panic("action already reduced!")
 	}
 
-	functionValue := reflect.ValueOf(function)
 	functionType := reflect.TypeOf(function)
 	if functionType.Kind() != reflect.Func {
 		panic("The function must be a Func!")
 	}
 
-	if typeOfState := reflect.TypeOf(builder.initialState); functionType.NumIn() < 1 || functionType.NumIn() > 2 || functionType.NumOut() != 1 || functionType.In(0) != functionType.Out(0) || functionType.In(0) != typeOfState {
-		panic(fmt.Errorf("the function for action `%v` must to have the contract func(state `%v`, payload *any) `%v`", action.GetType(), typeOfState.Name(), typeOfState.Name()))
+	if typeOfState := reflect.TypeOf(builder.initialState); !isReducerFunction(functionType, 0, typeOfState) {
+		panic(fmt.Errorf("the function for action `%v` must to have the contract func([ctx context.Context,] state `%v`, payload *any) `%v`", action.GetType(), typeOfState.Name(), typeOfState.Name()))
 	}
+	return functionType
+}
 
-	if functionType.NumIn() == 2 {
-		action.SetPayloadType(functionType.In(1))
+func isReducerFunction(functionType reflect.Type, first int, typeOfState reflect.Type) bool {
+	if acceptsContext(functionType, first) {
+		first++
 	}
+	numIn := functionType.NumIn() - first
+	return numIn >= 1 && numIn <= 2 && functionType.NumOut() == 1 && functionType.In(first) == functionType.Out(0) && functionType.In(first) == typeOfState
+}
 
-	builder.blf[action] = functionValue
-	return builder
+func getPayloadType(functionType reflect.Type, first int) reflect.Type {
+	if acceptsContext(functionType, first) {
+		first++
+	}
+	if functionType.NumIn() == first+2 {
+		return functionType.In(first + 1)
+	}
+	return nil
+}
+
+func acceptsContext(functionType reflect.Type, first int) bool {
+	return functionType.NumIn() > first && functionType.In(first) == contextType
 }
 
 func (builder *businessParamBuilder) SetActionsLogicByObject(object interface{}) BusinesParamBuilder {
@@ -102,11 +176,11 @@ func (builder *businessParamBuilder) SetActionsLogicByObject(object interface{})
 	for i := 0; i < rt.NumMethod(); i++ {
 		m := rt.Method(i)
 		mt := m.Type
-		if mt.NumIn() >= 2 && mt.NumIn() <= 3 && mt.NumOut() == 1 && mt.In(1) == mt.Out(0) && mt.In(1) == typeOfState {
+		if isReducerFunction(mt, 1, typeOfState) {
 			if builder.actionsObject.ContainsByName(m.Name) {
 				action := builder.actionsObject.GetActionByName(m.Name)
-				if mt.NumIn() == 3 {
-					action.SetPayloadType(mt.In(2))
+				if payloadType := getPayloadType(mt, 1); payloadType != nil {
+					action.SetPayloadType(payloadType)
 				}
 				builder.blf[action] = rv.Method(i)
 			} else {
@@ -117,8 +191,77 @@ func (builder *businessParamBuilder) SetActionsLogicByObject(object interface{})
 	return builder
 }
 
+func (builder *businessParamBuilder) WithHistory(limit int, actions *HistoryActions) BusinesParamBuilder {
+	errorschecker.CheckNilParameter(map[string]interface{}{"actions": actions})
+	if limit < 1 {
+		panic("The limit of the history must be greater than zero!")
+	}
+	builder.actionsObjectFactory.Create(actions)
+	actions.JumpTo.SetPayloadType(reflect.TypeOf(0))
+	builder.history = &HistoryParam{Limit: limit, Actions: actions}
+	return builder
+}
+
+func (builder *businessParamBuilder) FilterHistory(actions ...Action) BusinesParamBuilder {
+	if builder.history == nil {
+		panic("The history must be enabled with WithHistory before filtering it!")
+	}
+	for _, action := range actions {
+		errorschecker.CheckNilParameter(map[string]interface{}{"action": action})
+		builder.history.Filter = append(builder.history.Filter, action.GetOrigin())
+	}
+	return builder
+}
+
+func (builder *businessParamBuilder) GroupHistory(window time.Duration) BusinesParamBuilder {
+	if builder.history == nil {
+		panic("The history must be enabled with WithHistory before grouping it!")
+	}
+	builder.history.GroupWindow = window
+	return builder
+}
+
+func (builder *businessParamBuilder) SetPersistent(persistent bool) BusinesParamBuilder {
+	builder.persistent = persistent
+	return builder
+}
+
+func (builder *businessParamBuilder) SetEqualityFunc(equality EqualityFunc) BusinesParamBuilder {
+	errorschecker.CheckNilParameter(map[string]interface{}{"equality": equality})
+	builder.equality = equality
+	return builder
+}
+
+func (builder *businessParamBuilder) SetCloner(cloner Cloner) BusinesParamBuilder {
+	errorschecker.CheckNilParameter(map[string]interface{}{"cloner": cloner})
+	builder.cloner = cloner
+	return builder
+}
+
+func (builder *businessParamBuilder) SetReadOnlyState(readOnly bool) BusinesParamBuilder {
+	builder.readOnlyState = readOnly
+	return builder
+}
+
 func (builder *businessParamBuilder) GetBusinessParam() BusinessParam {
+	defer builder.reset()
+	return builder.build()
+}
+
+func (builder *businessParamBuilder) Build() (businessParam BusinessParam, err error) {
+	defer builder.reset()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = toError(recovered)
+		}
+		if err != nil {
+			businessParam, err = nil, newStoreErrorFrom(BusinessParamError, err)
+		}
+	}()
+	return builder.build(), nil
+}
 
+func (builder *businessParamBuilder) build() BusinessParam {
 	if builder.selector == "" {
 		panic("The selector can not be string empty!")
 	}
@@ -141,43 +284,63 @@ func (builder *businessParamBuilder) GetBusinessParam() BusinessParam {
 		reducerActions.blf[key] = value
 	}
 	reducer := reducerActions.Reducer
+	if builder.equality == nil {
+		builder.equality = DeepEquality
+	}
+	if builder.cloner == nil {
+		builder.cloner = DeepCloner
+	}
 	if builder.selector == "" {
 		builder.selector = strconv.Itoa(int(reflect.ValueOf(builder.initialState).Pointer()))
 	}
 
-	businessParam := builder.businessParamFactory.Create(
+	return builder.businessParamFactory.Create(
 		BusinessParamFactoryParamter{
 			builder.initialState,
 			&reducer,
 			builder.actionsObject,
 			builder.selector,
-	})
+			builder.extraActions,
+			builder.history,
+			builder.persistent,
+			builder.equality,
+			builder.cloner,
+			builder.readOnlyState,
+		})
+}
 
+func (builder *businessParamBuilder) reset() {
 	builder.initialState = nil
 	builder.actionsObject = nil
 	builder.selector = ""
+	builder.extraActions = nil
+	builder.history = nil
+	builder.persistent = false
+	builder.equality = nil
+	builder.cloner = nil
+	builder.readOnlyState = false
 	for k := range builder.blf {
 		delete(builder.blf, k)
 	}
-
-	return businessParam
 }
 
 func (ra *redueActions) Reducer(state interface{}, action Action) interface{} {
-	function, exists := ra.blf[action]
+	function, exists := ra.blf[action.GetOrigin()]
 	if !exists {
 		panic("The action is not located in the reducer function!")
 	}
-	var result interface{}
-	if typeOfState := reflect.TypeOf(state); (function.Type().NumIn() < 3 && function.Type().In(0) != typeOfState) || function.Type().NumIn() == 1 {
-		result = function.Call([]reflect.Value{
-			reflect.ValueOf(state),
-		})[0].Interface()
+	functionType := function.Type()
+	args := make([]reflect.Value, 0, functionType.NumIn())
+	if acceptsContext(functionType, 0) {
+		args = append(args, reflect.ValueOf(action.GetContext()))
+	}
+	if state == nil {
+		args = append(args, reflect.Zero(functionType.In(len(args))))
 	} else {
-		result = function.Call([]reflect.Value{
-			reflect.ValueOf(state),
-			action.GetPayload(),
-		})[0].Interface()
+		args = append(args, reflect.ValueOf(state))
+	}
+	if functionType.NumIn() > len(args) {
+		args = append(args, action.GetPayload())
 	}
-	return result
+	return function.Call(args)[0].Interface()
 }
@@ -0,0 +1,110 @@
+package redux_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/janmbaco/go-redux/src"
+	"github.com/janmbaco/go-redux/src/ioc/resolver"
+)
+
+func recovered(fn func()) (value interface{}) {
+	defer func() {
+		value = recover()
+	}()
+	fn()
+	return nil
+}
+
+func newCounterBuilder() (redux.BusinesParamBuilder, *counterActions) {
+	actions := &counterActions{}
+	builder := resolver.GetBusinessParamBuilder().NewBuilder()
+	builder.SetInitialState(0)
+	builder.SetActions(actions)
+	return builder, actions
+}
+
+func TestBuilderMethodsPanicImmediately(t *testing.T) {
+	increment := func(state int, payload int) int {
+		return state + payload
+	}
+	for _, test := range []struct {
+		name  string
+		call  func(builder redux.BusinesParamBuilder, actions *counterActions)
+		panic string
+	}{
+		{name: "SetSelector", call: func(builder redux.BusinesParamBuilder, actions *counterActions) {
+			builder.SetSelector("")
+		}, panic: "The selector can not be string empty!"},
+		{name: "On a foreign action", call: func(builder redux.BusinesParamBuilder, actions *counterActions) {
+			builder.On(redux.PatchAction, increment)
+		}, panic: "This action doesn`t belong to this BusinesObject!"},
+		{name: "On twice", call: func(builder redux.BusinesParamBuilder, actions *counterActions) {
+			builder.On(actions.Increment, increment)
+			builder.On(actions.Increment, increment)
+		}, panic: "action already reduced!"},
+		{name: "On without a function", call: func(builder redux.BusinesParamBuilder, actions *counterActions) {
+			builder.On(actions.Increment, 1)
+		}, panic: "The function must be a Func!"},
+		{name: "GetBusinessParam without selector", call: func(builder redux.BusinesParamBuilder, actions *counterActions) {
+			builder.GetBusinessParam()
+		}, panic: "The selector can not be string empty!"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			builder, actions := newCounterBuilder()
+			completed := false
+			value := recovered(func() {
+				test.call(builder, actions)
+				completed = true
+			})
+			if completed || value != test.panic {
+				t.Fatalf("the panic is %#v, want %#v", value, test.panic)
+			}
+		})
+	}
+}
+
+func TestSetActionsPanicsImmediately(t *testing.T) {
+	builder := resolver.GetBusinessParamBuilder().NewBuilder()
+	if value := recovered(func() { builder.SetActions(nil) }); value == nil {
+		t.Fatal("SetActions accepted nil")
+	}
+}
+
+func TestBuildReturnsABusinessParamError(t *testing.T) {
+	builder, actions := newCounterBuilder()
+	builder.On(actions.Increment, func(state int, payload int) int {
+		return state + payload
+	})
+	builder.SetSelector("counter")
+
+	param, err := builder.Build()
+	if param != nil || !errors.Is(err, redux.BusinessParamError) {
+		t.Fatalf("Build returned %v and %v, want a BusinessParamError", param, err)
+	}
+	var storeError redux.StoreError
+	if !errors.As(err, &storeError) || storeError.GetErrorType() != redux.BusinessParamError {
+		t.Fatalf("the error %v is not a StoreError of type BusinessParamError", err)
+	}
+	for _, name := range []string{"Reset", "Fail"} {
+		if !strings.Contains(err.Error(), "'"+name+"' is not defined") {
+			t.Fatalf("the error %q does not report the action %v", err, name)
+		}
+	}
+
+	builder, actions = newCounterBuilder()
+	builder.On(actions.Increment, func(state int, payload int) int {
+		return state + payload
+	})
+	builder.On(actions.Reset, func(state int) int {
+		return 0
+	})
+	builder.On(actions.Fail, func(state int) int {
+		return state
+	})
+	builder.SetSelector("counter")
+	if param, err := builder.Build(); err != nil || param.GetSelector() != "counter" {
+		t.Fatalf("Build returned %v and %v after a failed build, want the slice counter", param, err)
+	}
+}
@@ -7,6 +7,12 @@ type BusinessParamFactoryParamter struct {
 	Reducer       Reducer
 	ActionsObject ActionsObject
 	Selector      string
+	ExtraActions  []Action
+	History       *HistoryParam
+	Persistent    bool
+	Equality      EqualityFunc
+	Cloner        Cloner
+	ReadOnlyState bool
 }
 
 type BusinessParamFactory interface {
@@ -18,15 +24,21 @@ type businessParamFactory struct {
 }
 
 func NewBusinessParamFactory(container dependencyinjection.Container) BusinessParamFactory {
-	container.Register().AsType(new(BusinessParam), NewBusinessParam, map[uint]string{0: _initialState, 1: _reducer, 2: _actionsObject, 3: _selector})
-	return &businessParamFactory{container.Resolver()};
+	container.Register().AsType(new(BusinessParam), NewBusinessParam, map[uint]string{0: _initialState, 1: _reducer, 2: _actionsObject, 3: _selector, 4: _extraActions, 5: _history, 6: _persistent, 7: _equality, 8: _cloner, 9: _readOnlyState})
+	return &businessParamFactory{container.Resolver()}
 }
 
-func (b *businessParamFactory) Create(parameter BusinessParamFactoryParamter) BusinessParam{
+func (b *businessParamFactory) Create(parameter BusinessParamFactoryParamter) BusinessParam {
 	return b.resolver.Type(new(BusinessParam), map[string]interface{}{
 		_initialState:  parameter.InitialState,
 		_reducer:       parameter.Reducer,
 		_selector:      parameter.Selector,
 		_actionsObject: parameter.ActionsObject,
+		_extraActions:  parameter.ExtraActions,
+		_history:       parameter.History,
+		_persistent:    parameter.Persistent,
+		_equality:      parameter.Equality,
+		_cloner:        parameter.Cloner,
+		_readOnlyState: parameter.ReadOnlyState,
 	}).(BusinessParam)
-}
\ No newline at end of file
+}
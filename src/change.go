@@ -0,0 +1,49 @@
+package redux
+
+import (
+	"reflect"
+
+	"github.com/janmbaco/go-infrastructure/errors/errorschecker"
+)
+
+type Change struct {
+	Selector string
+	Action   Action
+	Prev     interface{}
+	Next     interface{}
+	Seq      uint64
+}
+
+var changeFuncType = reflect.TypeOf(func(Change) {})
+
+func (s *store) SubscribeChanges(fn *func(Change)) {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	errorschecker.CheckNilParameter(map[string]interface{}{"fn": fn})
+	s.changes.Subscribe(fn)
+}
+
+func (s *store) UnsubscribeChanges(fn *func(Change)) {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	errorschecker.CheckNilParameter(map[string]interface{}{"fn": fn})
+	s.changes.UnSubscribe(fn)
+}
+
+func (s *store) SubscribeToChanges(selector string, fn *func(Change)) {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	checkSelector(selector)
+	errorschecker.CheckNilParameter(map[string]interface{}{"fn": fn})
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	s.checkActiveSlice(selector)
+	s.stateManagements[selector].SubscribeChanges(fn)
+}
+
+func (s *store) UnsubscribeFromChanges(selector string, fn *func(Change)) {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	checkSelector(selector)
+	errorschecker.CheckNilParameter(map[string]interface{}{"fn": fn})
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	s.checkStateManager(selector)
+	s.stateManagements[selector].UnSubscribeChanges(fn)
+}
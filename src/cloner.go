@@ -0,0 +1,115 @@
+package redux
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+type Cloner func(state interface{}) interface{}
+
+type Cloneable interface {
+	Clone() interface{}
+}
+
+type cloneKey struct {
+	pointer uintptr
+	typ     reflect.Type
+	length  int
+}
+
+func DeepCloner(state interface{}) interface{} {
+	if state == nil {
+		return nil
+	}
+	return deepClone(reflect.ValueOf(state), make(map[cloneKey]reflect.Value)).Interface()
+}
+
+func MethodCloner(state interface{}) interface{} {
+	if cloneable, isCloneable := state.(Cloneable); isCloneable {
+		return cloneable.Clone()
+	}
+	return DeepCloner(state)
+}
+
+func ReadOnlyCloner(state interface{}) interface{} {
+	return state
+}
+
+func deepClone(value reflect.Value, visited map[cloneKey]reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return reflect.Zero(value.Type())
+		}
+		key := cloneKey{pointer: value.Pointer(), typ: value.Type()}
+		if clone, exists := visited[key]; exists {
+			return clone
+		}
+		clone := reflect.New(value.Type().Elem())
+		visited[key] = clone
+		clone.Elem().Set(deepClone(value.Elem(), visited))
+		return clone
+	case reflect.Interface:
+		if value.IsNil() {
+			return reflect.Zero(value.Type())
+		}
+		clone := reflect.New(value.Type()).Elem()
+		clone.Set(deepClone(value.Elem(), visited))
+		return clone
+	case reflect.Map:
+		if value.IsNil() {
+			return reflect.Zero(value.Type())
+		}
+		key := cloneKey{pointer: value.Pointer(), typ: value.Type()}
+		if clone, exists := visited[key]; exists {
+			return clone
+		}
+		clone := reflect.MakeMapWithSize(value.Type(), value.Len())
+		visited[key] = clone
+		iterator := value.MapRange()
+		for iterator.Next() {
+			clone.SetMapIndex(deepClone(iterator.Key(), visited), deepClone(iterator.Value(), visited))
+		}
+		return clone
+	case reflect.Slice:
+		if value.IsNil() {
+			return reflect.Zero(value.Type())
+		}
+		key := cloneKey{pointer: value.Pointer(), typ: value.Type(), length: value.Len()}
+		if clone, exists := visited[key]; exists {
+			return clone
+		}
+		clone := reflect.MakeSlice(value.Type(), value.Len(), value.Cap())
+		visited[key] = clone
+		for i := 0; i < value.Len(); i++ {
+			clone.Index(i).Set(deepClone(value.Index(i), visited))
+		}
+		return clone
+	case reflect.Array:
+		clone := reflect.New(value.Type()).Elem()
+		for i := 0; i < value.Len(); i++ {
+			clone.Index(i).Set(deepClone(value.Index(i), visited))
+		}
+		return clone
+	case reflect.Struct:
+		if !value.CanAddr() {
+			addressable := reflect.New(value.Type()).Elem()
+			addressable.Set(value)
+			value = addressable
+		}
+		clone := reflect.New(value.Type()).Elem()
+		clone.Set(value)
+		for i := 0; i < value.NumField(); i++ {
+			accessible(clone.Field(i)).Set(deepClone(accessible(value.Field(i)), visited))
+		}
+		return clone
+	}
+	return value
+}
+
+func accessible(field reflect.Value) reflect.Value {
+	if field.CanSet() {
+		return field
+	}
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+}
@@ -0,0 +1,159 @@
+package redux_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/janmbaco/go-redux/src"
+	"github.com/jinzhu/copier"
+)
+
+type node struct {
+	Name string
+	Next *node
+}
+
+type account struct {
+	Owner   string
+	balance int
+	tags    []string
+}
+
+type inventory struct {
+	Items   map[string]*item
+	Updated time.Time
+}
+
+type item struct {
+	Name     string
+	Quantity int
+}
+
+func TestDeepCloner(t *testing.T) {
+	updated := time.Date(2021, time.March, 4, 10, 30, 0, 0, time.FixedZone("CET", 3600))
+
+	for _, test := range []struct {
+		name   string
+		state  func() interface{}
+		mutate func(state interface{})
+		check  func(t *testing.T, original interface{}, clone interface{})
+	}{
+		{
+			name: "cyclic pointers",
+			state: func() interface{} {
+				first := &node{Name: "first"}
+				first.Next = &node{Name: "second", Next: first}
+				return first
+			},
+			mutate: func(state interface{}) {
+				state.(*node).Next.Name = "changed"
+			},
+			check: func(t *testing.T, original interface{}, clone interface{}) {
+				cloned := clone.(*node)
+				if cloned == original.(*node) || cloned.Next == original.(*node).Next {
+					t.Fatal("the clone shares pointers with the original")
+				}
+				if cloned.Next.Next != cloned {
+					t.Fatal("the cycle is not preserved in the clone")
+				}
+				if cloned.Next.Name != "second" {
+					t.Fatalf("the clone has been mutated: %v", cloned.Next.Name)
+				}
+			},
+		},
+		{
+			name: "unexported fields",
+			state: func() interface{} {
+				return &account{Owner: "jan", balance: 10, tags: []string{"gold"}}
+			},
+			mutate: func(state interface{}) {
+				state.(*account).balance = 20
+				state.(*account).tags[0] = "silver"
+			},
+			check: func(t *testing.T, original interface{}, clone interface{}) {
+				cloned := clone.(*account)
+				if cloned.Owner != "jan" || cloned.balance != 10 || cloned.tags[0] != "gold" {
+					t.Fatalf("the unexported fields are not deep cloned: %+v", cloned)
+				}
+			},
+		},
+		{
+			name: "maps of pointers",
+			state: func() interface{} {
+				return &inventory{Items: map[string]*item{"apple": {Name: "apple", Quantity: 1}}}
+			},
+			mutate: func(state interface{}) {
+				state.(*inventory).Items["apple"].Quantity = 2
+				state.(*inventory).Items["pear"] = &item{Name: "pear"}
+			},
+			check: func(t *testing.T, original interface{}, clone interface{}) {
+				cloned := clone.(*inventory)
+				if cloned.Items["apple"] == original.(*inventory).Items["apple"] {
+					t.Fatal("the clone shares the map values with the original")
+				}
+				if len(cloned.Items) != 1 || cloned.Items["apple"].Quantity != 1 {
+					t.Fatalf("the map has not been deep cloned: %+v", cloned.Items)
+				}
+			},
+		},
+		{
+			name: "time.Time",
+			state: func() interface{} {
+				return &inventory{Updated: updated}
+			},
+			mutate: func(state interface{}) {
+				state.(*inventory).Updated = state.(*inventory).Updated.Add(time.Hour)
+			},
+			check: func(t *testing.T, original interface{}, clone interface{}) {
+				cloned := clone.(*inventory)
+				if !cloned.Updated.Equal(updated) || cloned.Updated.Location().String() != "CET" {
+					t.Fatalf("the time has not been cloned: %v", cloned.Updated)
+				}
+			},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			original := test.state()
+			clone := redux.DeepCloner(original)
+			test.mutate(original)
+			test.check(t, original, clone)
+		})
+	}
+}
+
+func newBenchmarkState() *inventory {
+	state := &inventory{Items: make(map[string]*item), Updated: time.Now()}
+	for i := 0; i < 100; i++ {
+		name := "item" + strconv.Itoa(i)
+		state.Items[name] = &item{Name: name, Quantity: i}
+	}
+	return state
+}
+
+func BenchmarkDeepCloner(b *testing.B) {
+	state := newBenchmarkState()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		redux.DeepCloner(state)
+	}
+}
+
+func BenchmarkCopier(b *testing.B) {
+	state := newBenchmarkState()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		clone := &inventory{}
+		if err := copier.CopyWithOption(clone, state, copier.Option{DeepCopy: true}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkReadOnlyCloner(b *testing.B) {
+	state := newBenchmarkState()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		redux.ReadOnlyCloner(state)
+	}
+}
@@ -0,0 +1,142 @@
+package redux
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/janmbaco/go-infrastructure/errors/errorschecker"
+)
+
+type computedSlice struct {
+	dependencies []string
+	compute      func(states ...interface{}) interface{}
+}
+
+func (s *store) AddComputed(selector string, compute func(states ...interface{}) interface{}, dependencies ...string) {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	checkSelector(selector)
+	errorschecker.CheckNilParameter(map[string]interface{}{"compute": compute})
+	if len(dependencies) == 0 {
+		panic("The computed slice needs at least one dependency!")
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.reducers[selector]; exists {
+		panic(newStoreError(MultipleReducerForSelectorError, "Cannot add multiple Reducer with the same selector!"))
+	}
+	if _, exists := s.computed[selector]; exists {
+		panic(newStoreError(MultipleReducerForSelectorError, "Cannot add multiple Reducer with the same selector!"))
+	}
+	computed := make(map[string]*computedSlice, len(s.computed)+1)
+	for sel, slice := range s.computed {
+		computed[sel] = slice
+	}
+	computed[selector] = &computedSlice{dependencies: dependencies, compute: compute}
+	order := sortComputed(computed)
+	for _, dependency := range dependencies {
+		checkSelector(dependency)
+		s.checkStateManager(dependency)
+	}
+
+	s.computed = computed
+	s.computedOrder = order
+	state := s.computeState(selector)
+	if stateManagement, exists := s.stateManagements[selector]; exists {
+		if stateManagement.Update(state) {
+			s.versions[selector]++
+		}
+	} else {
+		s.stateManagements[selector] = s.stateManagementFactory.Create(StateManagementFactoryParamter{state, selector, s.publisher, DeepEquality, DeepCloner})
+	}
+}
+
+func (s *store) recompute(selectors []string) []SliceResult {
+	changed := make(map[string]bool, len(selectors))
+	for _, selector := range selectors {
+		changed[selector] = true
+	}
+	result := make([]SliceResult, 0)
+	for _, selector := range s.computedOrder {
+		if !s.dependsOn(selector, changed) {
+			continue
+		}
+		stateManagement := s.stateManagements[selector]
+		prevState := stateManagement.GetState()
+		if stateManagement.Update(s.computeState(selector)) {
+			changed[selector] = true
+			s.versions[selector]++
+			result = append(result, SliceResult{Selector: selector, PrevState: prevState, NextState: stateManagement.GetState(), Changed: true})
+		}
+	}
+	return result
+}
+
+func changedSelectors(slices []SliceResult) []string {
+	selectors := make([]string, 0, len(slices))
+	for _, slice := range slices {
+		if slice.Changed {
+			selectors = append(selectors, slice.Selector)
+		}
+	}
+	return selectors
+}
+
+func (s *store) dependsOn(selector string, changed map[string]bool) bool {
+	for _, dependency := range s.computed[selector].dependencies {
+		if changed[dependency] {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *store) computeState(selector string) interface{} {
+	computed := s.computed[selector]
+	states := make([]interface{}, 0, len(computed.dependencies))
+	for _, dependency := range computed.dependencies {
+		states = append(states, s.stateManagements[dependency].GetState())
+	}
+	return computed.compute(states...)
+}
+
+func sortComputed(computed map[string]*computedSlice) []string {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	marks := make(map[string]int, len(computed))
+	order := make([]string, 0, len(computed))
+	var visit func(selector string, path []string)
+	visit = func(selector string, path []string) {
+		slice, isComputed := computed[selector]
+		if !isComputed || marks[selector] == visited {
+			return
+		}
+		if marks[selector] == visiting {
+			for i := range path {
+				if path[i] == selector {
+					path = path[i:]
+					break
+				}
+			}
+			panic(newStoreError(ComputedCycleError, fmt.Sprintf("There is a cycle between the computed slices: '%v'!", append(path, selector))))
+		}
+		path = append(path, selector)
+		marks[selector] = visiting
+		for _, dependency := range slice.dependencies {
+			visit(dependency, path)
+		}
+		marks[selector] = visited
+		order = append(order, selector)
+	}
+	selectors := make([]string, 0, len(computed))
+	for selector := range computed {
+		selectors = append(selectors, selector)
+	}
+	sort.Strings(selectors)
+	for _, selector := range selectors {
+		visit(selector, make([]string, 0))
+	}
+	return order
+}
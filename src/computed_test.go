@@ -0,0 +1,78 @@
+package redux_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/janmbaco/go-redux/src"
+)
+
+func addDouble(store redux.Store, calls *int) {
+	store.AddComputed("double", func(states ...interface{}) interface{} {
+		*calls++
+		return states[0].(int) * 2
+	}, "counter")
+}
+
+func TestComputedSliceIsRecomputedWhenADependencyChanges(t *testing.T) {
+	store, actions := newCounterStore(t)
+	calls := 0
+	addDouble(store, &calls)
+	store.AddComputed("quadruple", func(states ...interface{}) interface{} {
+		return states[0].(int) * 2
+	}, "double")
+	changes := make([]redux.Change, 0)
+	onChange := func(change redux.Change) {
+		changes = append(changes, change)
+	}
+	store.SubscribeToChanges("quadruple", &onChange)
+
+	store.Dispatch(actions.Increment.With(2))
+
+	if state := store.GetStateOf("double"); state != 4 || calls != 2 {
+		t.Fatalf("the state is %v after %v calls, want 4 after 2 calls", state, calls)
+	}
+	if len(changes) != 1 || changes[0].Next != 8 || changes[0].Action.GetOrigin() != actions.Increment {
+		t.Fatalf("the changes are %v, want 8 by the action Increment", changes)
+	}
+}
+
+func TestComputedSliceIsNotRecomputedWithoutChangesInItsDependencies(t *testing.T) {
+	store, actions := newCounterStore(t)
+	labels := addLabel(store)
+	calls := 0
+	addDouble(store, &calls)
+
+	store.Dispatch(labels.Set.With("label"))
+	store.Dispatch(actions.Increment.With(0))
+
+	if calls != 1 {
+		t.Fatalf("the computed slice was calculated %v times, want 1", calls)
+	}
+}
+
+func TestAddComputedRejectsInvalidDependencies(t *testing.T) {
+	store, _ := newCounterStore(t)
+	for _, test := range []struct {
+		name         string
+		dependencies []string
+		err          error
+	}{
+		{name: "itself", dependencies: []string{"double"}, err: redux.ComputedCycleError},
+		{name: "missing", dependencies: []string{"counter", "missing"}, err: redux.AnyStateBySelectorError},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := catch(func() {
+				store.AddComputed("double", func(states ...interface{}) interface{} {
+					return 0
+				}, test.dependencies...)
+			})
+			if !errors.Is(err, test.err) {
+				t.Fatalf("the error is %v, want %v", err, test.err)
+			}
+			if err := catch(func() { store.GetStateOf("double") }); !errors.Is(err, redux.AnyStateBySelectorError) {
+				t.Fatalf("the rejected computed slice has been registered: %v", err)
+			}
+		})
+	}
+}
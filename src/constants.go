@@ -1,10 +1,16 @@
 package redux
 
 const (
-	_initialState = "initialState"
-	_selector = "selector"
+	_initialState   = "initialState"
+	_selector       = "selector"
 	_storePublisher = "storePublisher"
-	_reducer = "reducer"
-	_actionsObject = "actionsObject"
-	_actions = "actions"
+	_reducer        = "reducer"
+	_actionsObject  = "actionsObject"
+	_actions        = "actions"
+	_extraActions   = "extraActions"
+	_history        = "history"
+	_persistent     = "persistent"
+	_equality       = "equality"
+	_cloner         = "cloner"
+	_readOnlyState  = "readOnlyState"
 )
@@ -0,0 +1,100 @@
+package redux_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/janmbaco/go-redux/src"
+)
+
+func TestDispatchContextRefusesADoneContext(t *testing.T) {
+	store, actions := newCounterStore(t)
+	reached := false
+	store.ApplyMiddleware(func(next redux.DispatchFunc) redux.DispatchFunc {
+		return func(action redux.Action) redux.DispatchResult {
+			reached = true
+			return next(action)
+		}
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := store.TryDispatchContext(ctx, actions.Increment.With(1)); !errors.Is(err, redux.ContextDoneError) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("the error is %v, want a ContextDoneError wrapping %v", err, context.Canceled)
+	}
+	if reached {
+		t.Fatal("the middleware has been called with a done context")
+	}
+	if state := store.GetStateOf("counter"); state != 0 {
+		t.Fatalf("the state is %v, want 0", state)
+	}
+}
+
+func TestDispatchContextIsNotReducedWhenTheContextIsDoneDuringTheDispatch(t *testing.T) {
+	store, actions := newCounterStore(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	store.ApplyMiddleware(func(next redux.DispatchFunc) redux.DispatchFunc {
+		return func(action redux.Action) redux.DispatchResult {
+			cancel()
+			return next(action)
+		}
+	})
+	notified := false
+	onChange := func(interface{}) {
+		notified = true
+	}
+	store.SubscribeTo("counter", &onChange)
+
+	if err := store.TryDispatchContext(ctx, actions.Increment.With(1)); !errors.Is(err, redux.ContextDoneError) {
+		t.Fatalf("the error is %v, want a ContextDoneError", err)
+	}
+	if state := store.GetStateOf("counter"); state != 0 {
+		t.Fatalf("the state is %v, want 0", state)
+	}
+	if notified {
+		t.Fatal("the subscribers were notified of a cancelled dispatch")
+	}
+}
+
+func TestDispatchContextReachesTheMiddlewareAndTheEffects(t *testing.T) {
+	store, actions := newCounterStore(t)
+	var received interface{}
+	store.ApplyMiddleware(func(next redux.DispatchFunc) redux.DispatchFunc {
+		return func(action redux.Action) redux.DispatchResult {
+			received = action.GetContext().Value(requestKey{})
+			return next(action)
+		}
+	})
+	started := make(chan interface{}, 1)
+	cancelled := make(chan struct{})
+	effect := redux.Effect(func(ctx context.Context, result redux.DispatchResult) error {
+		started <- ctx.Value(requestKey{})
+		<-ctx.Done()
+		close(cancelled)
+		return nil
+	})
+	store.AddEffect(actions.Increment, redux.TakeEvery, &effect)
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), requestKey{}, "42"))
+
+	store.DispatchContext(ctx, actions.Increment.With(1))
+
+	if received != "42" {
+		t.Fatalf("the middleware received the value %v, want 42", received)
+	}
+	select {
+	case value := <-started:
+		if value != "42" {
+			t.Fatalf("the effect received the value %v, want 42", value)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the effect has not been called")
+	}
+	cancel()
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("the effect has not been cancelled with the context of the dispatch")
+	}
+}
@@ -0,0 +1,61 @@
+package redux_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/janmbaco/go-redux/src"
+	"github.com/janmbaco/go-redux/src/ioc/resolver"
+)
+
+type catalogMutations struct {
+	Mutate redux.Action
+	Add    redux.Action
+}
+
+func TestDevModeReportsMutationsOfTheStatePassedToTheReducer(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		cloner   redux.Cloner
+		mutating bool
+		want     bool
+		state    string
+	}{
+		{name: "mutating reducer with DeepCloner", cloner: redux.DeepCloner, mutating: true, want: true, state: "pen"},
+		{name: "mutating reducer with ReadOnlyCloner", cloner: redux.ReadOnlyCloner, mutating: true, want: true, state: "book"},
+		{name: "pure reducer with DeepCloner", cloner: redux.DeepCloner, mutating: false, want: false, state: "book"},
+		{name: "pure reducer with ReadOnlyCloner", cloner: redux.ReadOnlyCloner, mutating: false, want: false, state: "book"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			actions := &catalogMutations{}
+			builder := resolver.GetBusinessParamBuilder()
+			builder.SetInitialState(&catalog{Products: []string{"pen"}})
+			builder.SetActions(actions)
+			builder.On(actions.Mutate, func(state *catalog, product string) *catalog {
+				state.Products[0] = product
+				return state
+			})
+			builder.On(actions.Add, func(state *catalog, product string) *catalog {
+				return &catalog{Products: []string{product}}
+			})
+			builder.SetCloner(test.cloner)
+			builder.SetSelector("catalog")
+			store := resolver.GetStore()
+			store.AddReducer(builder.GetBusinessParam())
+			defer store.Shutdown()
+			store.SetDevMode(true)
+
+			action := actions.Add
+			if test.mutating {
+				action = actions.Mutate
+			}
+			err := store.TryDispatch(action.With("book"))
+			if got := errors.Is(err, redux.MutationError); got != test.want {
+				t.Fatalf("the mutation has been reported: %v, want %v (%v)", got, test.want, err)
+			}
+			if products := store.GetStateOf("catalog").(*catalog).Products; len(products) != 1 || products[0] != test.state {
+				t.Fatalf("the state is %v, want [%v]", products, test.state)
+			}
+		})
+	}
+}
@@ -0,0 +1,656 @@
+package devtools
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/janmbaco/go-infrastructure/errors/errorschecker"
+	"github.com/janmbaco/go-redux/src"
+	"github.com/janmbaco/go-redux/src/jsonpatch"
+)
+
+const (
+	DefaultURL     = "ws://localhost:8000/socketcluster/"
+	DefaultName    = "go-redux"
+	DefaultTimeout = 10 * time.Second
+)
+
+var errDiscarded = errors.New("the actions are only reduced to compute their states")
+
+type Options struct {
+	URL     string
+	Name    string
+	Timeout time.Duration
+}
+
+type Bridge interface {
+	redux.ActionLog
+	Connect() error
+	Close() error
+}
+
+type request struct {
+	Type   string          `json:"type"`
+	Action json.RawMessage `json:"action"`
+	State  json.RawMessage `json:"state"`
+}
+
+type command struct {
+	Type            string          `json:"type"`
+	Index           int             `json:"index"`
+	ActionID        int             `json:"actionId"`
+	ID              int             `json:"id"`
+	Status          bool            `json:"status"`
+	NextLiftedState json.RawMessage `json:"nextLiftedState"`
+}
+
+type message struct {
+	Type           string `json:"type"`
+	ID             string `json:"id,omitempty"`
+	Name           string `json:"name"`
+	InstanceID     string `json:"instanceId"`
+	Action         string `json:"action,omitempty"`
+	Payload        string `json:"payload,omitempty"`
+	CommittedState string `json:"committedState,omitempty"`
+	NextActionID   int    `json:"nextActionId,omitempty"`
+}
+
+type bridge struct {
+	store     redux.Store
+	previous  redux.ActionLog
+	options   Options
+	mutex     sync.Mutex
+	sc        *socketCluster
+	pending   []pendingRecord
+	state     map[string]interface{}
+	onResult  func(redux.DispatchResult)
+	lastSeq   uint64
+	paused    bool
+	traveling bool
+	signal    chan struct{}
+	requests  chan request
+	done      chan struct{}
+	closed    sync.Once
+
+	started        bool
+	initialState   json.RawMessage
+	committedState json.RawMessage
+	records        []record
+	skipped        map[int]bool
+	current        int
+}
+
+func NewBridge(store redux.Store, options Options) Bridge {
+	errorschecker.CheckNilParameter(map[string]interface{}{"store": store})
+	if options.URL == "" {
+		options.URL = DefaultURL
+	}
+	if options.Name == "" {
+		options.Name = DefaultName
+	}
+	if options.Timeout == 0 {
+		options.Timeout = DefaultTimeout
+	}
+
+	b := &bridge{
+		store:    store,
+		previous: store.GetActionLog(),
+		options:  options,
+		signal:   make(chan struct{}, 1),
+		requests: make(chan request, 64),
+		done:     make(chan struct{}),
+		started:  true,
+		records:  make([]record, 0),
+		skipped:  make(map[int]bool),
+	}
+	b.onResult = b.capture
+	store.SubscribeResults(&b.onResult)
+	var state interface{}
+	errorschecker.TryPanic(try(func() {
+		state = store.GetState()
+	}))
+	b.state = state.(map[string]interface{})
+	initialState, err := json.Marshal(b.state)
+	errorschecker.TryPanic(err)
+	b.initialState = initialState
+	b.committedState = initialState
+	store.SetActionLog(b)
+	go b.loop()
+	return b
+}
+
+func (b *bridge) Append(entries ...redux.ActionLogEntry) error {
+	if b.previous != nil {
+		if err := b.previous.Append(entries...); err != nil {
+			return err
+		}
+	}
+	b.mutex.Lock()
+	for _, entry := range entries {
+		if entry.Seq > b.lastSeq {
+			b.lastSeq = entry.Seq
+		}
+		if !b.paused && !b.traveling {
+			b.pending = append(b.pending, pendingRecord{record: record{entry: entry}})
+		}
+	}
+	b.mutex.Unlock()
+	return nil
+}
+
+func (b *bridge) capture(result redux.DispatchResult) {
+	b.mutex.Lock()
+	for _, slice := range result.Slices {
+		b.state[slice.Selector] = slice.NextState
+	}
+	captured := false
+	for i := range b.pending {
+		if b.pending[i].entry.Seq <= result.Seq && !b.pending[i].captured {
+			b.pending[i].state, b.pending[i].err = json.Marshal(b.state)
+			b.pending[i].captured = true
+			captured = true
+		}
+	}
+	b.mutex.Unlock()
+	if captured {
+		select {
+		case b.signal <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (b *bridge) Entries(fromSeq uint64) ([]redux.ActionLogEntry, error) {
+	if b.previous == nil {
+		return make([]redux.ActionLogEntry, 0), nil
+	}
+	return b.previous.Entries(fromSeq)
+}
+
+func (b *bridge) LastSeq() (uint64, error) {
+	if b.previous == nil {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+		return b.lastSeq, nil
+	}
+	return b.previous.LastSeq()
+}
+
+func (b *bridge) Connect() error {
+	select {
+	case <-b.done:
+		return errors.New("the bridge is closed")
+	default:
+	}
+	sc, err := dialSocketCluster(b.options.URL, b.options.Timeout)
+	if err != nil {
+		return err
+	}
+	data, err := sc.call("login", "master")
+	if err != nil {
+		sc.Close()
+		return err
+	}
+	var channel string
+	if err := json.Unmarshal(data, &channel); err != nil {
+		sc.Close()
+		return err
+	}
+	if err := sc.subscribe(channel); err != nil {
+		sc.Close()
+		return err
+	}
+
+	b.mutex.Lock()
+	if b.sc != nil {
+		b.sc.Close()
+	}
+	b.sc = sc
+	b.mutex.Unlock()
+	go b.listen(sc, channel)
+	select {
+	case b.requests <- request{Type: "START"}:
+	case <-b.done:
+	}
+	return nil
+}
+
+func (b *bridge) Close() error {
+	var err error
+	b.closed.Do(func() {
+		close(b.done)
+		b.store.UnsubscribeResults(&b.onResult)
+		b.mutex.Lock()
+		sc := b.sc
+		b.sc = nil
+		b.mutex.Unlock()
+		if sc != nil {
+			err = sc.Close()
+		}
+		if b.store.GetActionLog() == b {
+			b.store.SetActionLog(b.previous)
+		}
+	})
+	return err
+}
+
+func (b *bridge) listen(sc *socketCluster, channel string) {
+	defer func() {
+		b.mutex.Lock()
+		if b.sc == sc {
+			b.sc = nil
+		}
+		b.mutex.Unlock()
+	}()
+	for {
+		received, err := sc.read()
+		if err != nil {
+			return
+		}
+		data := received.Data
+		switch received.Event {
+		case "#publish":
+			var published publication
+			if err := json.Unmarshal(received.Data, &published); err != nil || published.Channel != channel {
+				continue
+			}
+			data = published.Data
+		case channel:
+		default:
+			continue
+		}
+		var incoming request
+		if err := json.Unmarshal(data, &incoming); err != nil {
+			continue
+		}
+		select {
+		case b.requests <- incoming:
+		case <-b.done:
+			return
+		}
+	}
+}
+
+func (b *bridge) loop() {
+	for {
+		select {
+		case <-b.done:
+			return
+		case <-b.signal:
+			b.record()
+		case incoming := <-b.requests:
+			b.record()
+			if err := b.handle(incoming); err != nil {
+				b.send(message{Type: "ERROR", Payload: err.Error()})
+			}
+		}
+	}
+}
+
+func (b *bridge) record() {
+	b.mutex.Lock()
+	captured := 0
+	for captured < len(b.pending) && b.pending[captured].captured {
+		captured++
+	}
+	records := append([]pendingRecord(nil), b.pending[:captured]...)
+	b.pending = b.pending[captured:]
+	b.mutex.Unlock()
+	if len(records) == 0 {
+		return
+	}
+	if b.current < len(b.records) {
+		for id := range b.skipped {
+			if id > b.current {
+				delete(b.skipped, id)
+			}
+		}
+		b.records = b.records[:b.current]
+	}
+	for _, pending := range records {
+		if pending.err != nil {
+			b.send(message{Type: "ERROR", Payload: pending.err.Error()})
+			continue
+		}
+		b.records = append(b.records, pending.record)
+		b.current = len(b.records)
+		if b.started {
+			lifted, _ := json.Marshal(toLiftedAction(pending.entry))
+			b.send(message{Type: "ACTION", Action: string(lifted), Payload: string(pending.state), NextActionID: b.current + 1})
+		}
+	}
+}
+
+func (b *bridge) handle(incoming request) error {
+	switch incoming.Type {
+	case "START":
+		b.started = true
+		return b.sendState()
+	case "STOP":
+		b.started = false
+		return nil
+	case "UPDATE":
+		return b.sendState()
+	case "ACTION":
+		return b.dispatch(incoming.Action)
+	case "DISPATCH":
+		var received command
+		if err := json.Unmarshal(incoming.Action, &received); err != nil {
+			return err
+		}
+		if err := b.travel(received); err != nil {
+			return err
+		}
+		return b.sendState()
+	case "IMPORT":
+		if err := b.importState(incoming.State); err != nil {
+			return err
+		}
+		return b.sendState()
+	case "EXPORT":
+		return b.export()
+	}
+	return nil
+}
+
+func (b *bridge) dispatch(raw json.RawMessage) error {
+	var received action
+	if err := unmarshalEncoded(raw, &received); err != nil {
+		return err
+	}
+	entry, err := toEntry(received)
+	if err != nil {
+		return err
+	}
+	decoded, err := b.store.DecodeAction(entry)
+	if err != nil {
+		return err
+	}
+	return b.store.TryDispatch(decoded)
+}
+
+func (b *bridge) travel(received command) error {
+	switch received.Type {
+	case "JUMP_TO_STATE":
+		return b.jump(received.Index)
+	case "JUMP_TO_ACTION":
+		return b.jump(received.ActionID)
+	case "TOGGLE_ACTION":
+		if received.ID < 1 || received.ID > len(b.records) {
+			return fmt.Errorf("there is not any action with the id '%v'", received.ID)
+		}
+		b.toggle(received.ID)
+		if err := b.recompute(received.ID); err != nil {
+			b.toggle(received.ID)
+			return err
+		}
+	case "RESET":
+		b.records = make([]record, 0)
+		b.skipped = make(map[int]bool)
+		b.current = 0
+		b.committedState = b.initialState
+		return b.restore(b.initialState)
+	case "COMMIT":
+		b.committedState = b.stateAt(b.current)
+		b.records = make([]record, 0)
+		b.skipped = make(map[int]bool)
+		b.current = 0
+	case "ROLLBACK":
+		b.records = make([]record, 0)
+		b.skipped = make(map[int]bool)
+		b.current = 0
+		return b.restore(b.committedState)
+	case "SWEEP":
+		records := make([]record, 0, len(b.records))
+		current := 0
+		for i, record := range b.records {
+			if b.skipped[i+1] {
+				continue
+			}
+			records = append(records, record)
+			if i < b.current {
+				current = len(records)
+			}
+		}
+		b.records = records
+		b.skipped = make(map[int]bool)
+		b.current = current
+	case "PAUSE_RECORDING":
+		b.mutex.Lock()
+		b.paused = received.Status
+		b.mutex.Unlock()
+	case "IMPORT_STATE":
+		return b.importState(received.NextLiftedState)
+	}
+	return nil
+}
+
+func (b *bridge) jump(index int) error {
+	if index < 0 || index > len(b.records) {
+		return fmt.Errorf("there is not any state with the index '%v'", index)
+	}
+	if err := b.restore(b.stateAt(index)); err != nil {
+		return err
+	}
+	b.current = index
+	return nil
+}
+
+func (b *bridge) toggle(id int) {
+	b.skipped[id] = !b.skipped[id]
+	if !b.skipped[id] {
+		delete(b.skipped, id)
+	}
+}
+
+func (b *bridge) recompute(from int) error {
+	if err := b.restore(b.stateAt(from - 1)); err != nil {
+		return err
+	}
+	err := b.reduce(from)
+	if restoreErr := b.restore(b.stateAt(b.current)); err == nil {
+		err = restoreErr
+	}
+	return err
+}
+
+func (b *bridge) reduce(from int) error {
+	var state map[string]json.RawMessage
+	if err := json.Unmarshal(b.stateAt(from-1), &state); err != nil {
+		return err
+	}
+	selectors := b.store.GetActionsObjects()
+	states := make([]json.RawMessage, 0, len(b.records)-from+1)
+	reduced := false
+	err := try(func() {
+		b.store.Transaction(func(tx redux.Tx) error {
+			for index := from; index <= len(b.records); index++ {
+				if !b.skipped[index] {
+					action, err := b.store.DecodeAction(b.records[index-1].entry)
+					if err != nil {
+						return err
+					}
+					tx.Dispatch(action)
+				}
+				for selector := range selectors {
+					sliceState, err := json.Marshal(tx.GetStateOf(selector))
+					if err != nil {
+						return err
+					}
+					state[selector] = sliceState
+				}
+				next, err := json.Marshal(state)
+				if err != nil {
+					return err
+				}
+				states = append(states, next)
+			}
+			reduced = true
+			return errDiscarded
+		})
+	})
+	if !reduced {
+		return err
+	}
+	for i, next := range states {
+		b.records[from-1+i].state = next
+	}
+	return nil
+}
+
+func (b *bridge) restore(raw json.RawMessage) error {
+	var state map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return err
+	}
+	b.mutex.Lock()
+	b.traveling = true
+	b.mutex.Unlock()
+	defer func() {
+		b.mutex.Lock()
+		b.traveling = false
+		b.mutex.Unlock()
+	}()
+	for selector := range b.store.GetActionsObjects() {
+		next, exists := state[selector]
+		if !exists {
+			continue
+		}
+		if err := try(func() {
+			patch, err := jsonpatch.Diff(b.store.GetStateOf(selector), next)
+			errorschecker.TryPanic(err)
+			if len(patch) > 0 {
+				b.store.ApplyPatch(selector, patch)
+			}
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *bridge) importState(raw json.RawMessage) error {
+	var imported liftedState
+	if err := unmarshalEncoded(raw, &imported); err != nil {
+		return err
+	}
+	skipped := make(map[int]bool, len(imported.SkippedActionIDs))
+	for _, id := range imported.SkippedActionIDs {
+		skipped[id] = true
+	}
+	records := make([]record, 0, len(imported.StagedActionIDs))
+	importedSkipped := make(map[int]bool)
+	for _, id := range imported.StagedActionIDs {
+		lifted, exists := imported.ActionsByID[id]
+		if !exists || lifted.Action.Type == initAction {
+			continue
+		}
+		entry, err := toEntry(lifted.Action)
+		if err != nil {
+			return err
+		}
+		entry.Timestamp = time.UnixMilli(lifted.Timestamp)
+		records = append(records, record{entry: entry})
+		if skipped[id] {
+			importedSkipped[len(records)] = true
+		}
+	}
+
+	committedState := b.committedState
+	if len(imported.CommittedState) > 0 && string(imported.CommittedState) != "null" {
+		committedState = imported.CommittedState
+	}
+	current := imported.CurrentStateIndex
+	if current < 0 || current > len(records) {
+		current = len(records)
+	}
+
+	previousRecords, previousSkipped, previousCurrent, previousCommittedState := b.records, b.skipped, b.current, b.committedState
+	b.records, b.skipped, b.current, b.committedState = records, importedSkipped, current, committedState
+	if err := b.recompute(1); err != nil {
+		b.records, b.skipped, b.current, b.committedState = previousRecords, previousSkipped, previousCurrent, previousCommittedState
+		b.restore(b.stateAt(b.current))
+		return err
+	}
+	return nil
+}
+
+func (b *bridge) export() error {
+	actions := make([]action, 0, len(b.records))
+	for _, record := range b.records {
+		actions = append(actions, toAction(record.entry))
+	}
+	payload, err := json.Marshal(actions)
+	if err != nil {
+		return err
+	}
+	b.send(message{Type: "EXPORT", Payload: string(payload), CommittedState: string(b.committedState)})
+	return nil
+}
+
+func (b *bridge) sendState() error {
+	lifted := liftedState{
+		ActionsByID:       map[int]liftedAction{0: {Type: performAction, Action: action{Type: initAction}}},
+		ComputedStates:    []computedState{{State: b.committedState}},
+		CommittedState:    b.committedState,
+		CurrentStateIndex: b.current,
+		NextActionID:      len(b.records) + 1,
+		SkippedActionIDs:  make([]int, 0, len(b.skipped)),
+		StagedActionIDs:   []int{0},
+	}
+	for i, record := range b.records {
+		id := i + 1
+		lifted.ActionsByID[id] = toLiftedAction(record.entry)
+		lifted.ComputedStates = append(lifted.ComputedStates, computedState{State: record.state})
+		lifted.StagedActionIDs = append(lifted.StagedActionIDs, id)
+		if b.skipped[id] {
+			lifted.SkippedActionIDs = append(lifted.SkippedActionIDs, id)
+		}
+	}
+	b.mutex.Lock()
+	lifted.IsPaused = b.paused
+	b.mutex.Unlock()
+	payload, err := json.Marshal(lifted)
+	if err != nil {
+		return err
+	}
+	b.send(message{Type: "STATE", Payload: string(payload)})
+	return nil
+}
+
+func (b *bridge) send(sent message) {
+	b.mutex.Lock()
+	sc := b.sc
+	b.mutex.Unlock()
+	if sc == nil {
+		return
+	}
+	sent.ID = sc.id
+	sent.Name = b.options.Name
+	sent.InstanceID = b.options.Name
+	sc.emit("log", sent)
+}
+
+func (b *bridge) stateAt(index int) json.RawMessage {
+	if index == 0 {
+		return b.committedState
+	}
+	return b.records[index-1].state
+}
+
+func try(fn func()) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if recoveredError, isError := recovered.(error); isError {
+				err = recoveredError
+			} else {
+				err = fmt.Errorf("%v", recovered)
+			}
+		}
+	}()
+	fn()
+	return nil
+}
@@ -0,0 +1,366 @@
+package devtools
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/janmbaco/go-infrastructure/dependencyinjection/static"
+	"github.com/janmbaco/go-redux/src"
+	"github.com/janmbaco/go-redux/src/ioc/resolver"
+)
+
+type counterActions struct {
+	Increment redux.Action
+}
+
+func init() {
+	static.Container.Register().AsType(new(redux.Store), redux.NewStore, nil)
+}
+
+func acceptWebSocket(w http.ResponseWriter, r *http.Request) (*webSocket, error) {
+	conn, buffer, err := w.(http.Hijacker).Hijack()
+	if err != nil {
+		return nil, err
+	}
+	hash := sha1.Sum([]byte(r.Header.Get("Sec-WebSocket-Key") + webSocketGUID))
+	response := "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(hash[:]) + "\r\n\r\n"
+	if _, err := conn.Write([]byte(response)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &webSocket{conn: conn, reader: buffer.Reader}, nil
+}
+
+func newRemoteDevServer(t *testing.T) (string, <-chan message, chan<- request) {
+	t.Helper()
+	logs := make(chan message, 64)
+	requests := make(chan request)
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := acceptWebSocket(w, r)
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+		sc := &socketCluster{ws: ws}
+		var mutex sync.Mutex
+		write := func(sent packet, data interface{}) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+			return sc.write(sent, data)
+		}
+		for {
+			received, err := sc.read()
+			if err != nil {
+				return
+			}
+			switch received.Event {
+			case "#handshake":
+				err = write(packet{RID: received.CID}, handshake{ID: "socket"})
+			case "login":
+				err = write(packet{RID: received.CID}, "respond")
+			case "#subscribe":
+				go func() {
+					for {
+						select {
+						case sent := <-requests:
+							write(packet{Event: "respond"}, sent)
+						case <-done:
+							return
+						}
+					}
+				}()
+			case "log":
+				var logged message
+				if err := json.Unmarshal(received.Data, &logged); err == nil {
+					logs <- logged
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(func() {
+		close(done)
+		server.Close()
+	})
+	return "ws" + strings.TrimPrefix(server.URL, "http") + "/socketcluster/", logs, requests
+}
+
+func receive(t *testing.T, logs <-chan message, messageType string) message {
+	t.Helper()
+	for {
+		select {
+		case logged := <-logs:
+			if logged.Type == messageType {
+				return logged
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("the message '%v' has not been received", messageType)
+		}
+	}
+}
+
+func receiveState(t *testing.T, logs <-chan message) liftedState {
+	t.Helper()
+	var lifted liftedState
+	if err := json.Unmarshal([]byte(receive(t, logs, "STATE").Payload), &lifted); err != nil {
+		t.Fatal(err)
+	}
+	return lifted
+}
+
+func counterOf(t *testing.T, raw json.RawMessage) int {
+	t.Helper()
+	var state map[string]int
+	if err := json.Unmarshal(raw, &state); err != nil {
+		t.Fatal(err)
+	}
+	return state["counter"]
+}
+
+func newCounterStore(t *testing.T) (redux.Store, *counterActions) {
+	t.Helper()
+	actions := &counterActions{}
+	builder := resolver.GetBusinessParamBuilder().NewBuilder()
+	builder.SetInitialState(0)
+	builder.SetActions(actions)
+	builder.On(actions.Increment, func(state int, payload int) int {
+		return state + payload
+	})
+	builder.SetSelector("counter")
+	store := resolver.GetStore()
+	store.AddReducer(builder.GetBusinessParam())
+	t.Cleanup(store.Shutdown)
+	return store, actions
+}
+
+func connect(t *testing.T, store redux.Store) (<-chan message, chan<- request) {
+	t.Helper()
+	url, logs, requests := newRemoteDevServer(t)
+	bridge := NewBridge(store, Options{URL: url, Timeout: 5 * time.Second})
+	t.Cleanup(func() {
+		bridge.Close()
+	})
+	if err := bridge.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	receive(t, logs, "STATE")
+	return logs, requests
+}
+
+func dispatchEach(t *testing.T, store redux.Store, logs <-chan message, actions ...redux.Action) {
+	t.Helper()
+	for _, action := range actions {
+		store.Dispatch(action)
+		receive(t, logs, "ACTION")
+	}
+}
+
+func travel(t *testing.T, requests chan<- request, logs <-chan message, received command) liftedState {
+	t.Helper()
+	data, err := json.Marshal(received)
+	if err != nil {
+		t.Fatal(err)
+	}
+	requests <- request{Type: "DISPATCH", Action: data}
+	return receiveState(t, logs)
+}
+
+func checkComputedStates(t *testing.T, lifted liftedState, want ...int) {
+	t.Helper()
+	if len(lifted.ComputedStates) != len(want) {
+		t.Fatalf("there are %v computed states, want %v", len(lifted.ComputedStates), want)
+	}
+	for i, computed := range lifted.ComputedStates {
+		if state := counterOf(t, computed.State); state != want[i] {
+			t.Fatalf("the computed state %v is %v, want %v", i, state, want[i])
+		}
+	}
+}
+
+func TestBridgeSendsTheStateOfEachAction(t *testing.T) {
+	store, actions := newCounterStore(t)
+	logs, _ := connect(t, store)
+
+	store.Dispatch(actions.Increment.With(1))
+	store.Dispatch(actions.Increment.With(2))
+	store.DispatchBatch(actions.Increment.With(3), actions.Increment.With(4))
+
+	for _, want := range []int{1, 3, 6, 10} {
+		logged := receive(t, logs, "ACTION")
+		var state map[string]int
+		if err := json.Unmarshal([]byte(logged.Payload), &state); err != nil {
+			t.Fatal(err)
+		}
+		if state["counter"] != want {
+			t.Fatalf("the state sent with the action is %v, want %v", state["counter"], want)
+		}
+	}
+}
+
+func TestBridgeWritesToThePreviousActionLogAndRestoresIt(t *testing.T) {
+	store, actions := newCounterStore(t)
+	log := redux.NewMemoryActionLog()
+	store.SetActionLog(log)
+	bridge := NewBridge(store, Options{})
+
+	store.Dispatch(actions.Increment.With(1))
+	if err := bridge.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if store.GetActionLog() != log {
+		t.Fatal("the previous action log has not been restored")
+	}
+	if entries, _ := log.Entries(0); len(entries) != 1 || entries[0].Action != "Increment" {
+		t.Fatalf("the entries of the previous log are %v, want the action Increment", entries)
+	}
+}
+
+func TestBridgeJumpsWithPatchesThatAreLogged(t *testing.T) {
+	store, actions := newCounterStore(t)
+	log := redux.NewMemoryActionLog()
+	store.SetActionLog(log)
+	logs, requests := connect(t, store)
+	dispatchEach(t, store, logs, actions.Increment.With(1), actions.Increment.With(2), actions.Increment.With(4))
+	changes := make([]redux.Change, 0)
+	onChange := func(change redux.Change) {
+		changes = append(changes, change)
+	}
+	store.SubscribeChanges(&onChange)
+
+	lifted := travel(t, requests, logs, command{Type: "JUMP_TO_ACTION", ActionID: 1})
+	if state := store.GetStateOf("counter"); state != 1 || lifted.CurrentStateIndex != 1 {
+		t.Fatalf("the state is %v at the index %v, want 1 at the index 1", state, lifted.CurrentStateIndex)
+	}
+	lifted = travel(t, requests, logs, command{Type: "JUMP_TO_STATE", Index: 3})
+	if state := store.GetStateOf("counter"); state != 7 || lifted.CurrentStateIndex != 3 {
+		t.Fatalf("the state is %v at the index %v, want 7 at the index 3", state, lifted.CurrentStateIndex)
+	}
+	checkComputedStates(t, lifted, 0, 1, 3, 7)
+
+	if len(changes) != 2 || changes[0].Action.GetOrigin() != redux.PatchAction {
+		t.Fatalf("the changes are %v, want 2 patches", changes)
+	}
+	entries, _ := log.Entries(0)
+	if len(entries) != 5 || entries[3].Action != "@@PATCH" || entries[4].Action != "@@PATCH" {
+		t.Fatalf("the entries of the log are %v, want 3 actions and 2 patches", entries)
+	}
+	replayed, _ := newCounterStore(t)
+	replayed.Replay(log, 0)
+	if state := replayed.GetStateOf("counter"); state != 7 {
+		t.Fatalf("the replayed state is %v, want 7", state)
+	}
+}
+
+func TestBridgeTogglesAnAction(t *testing.T) {
+	store, actions := newCounterStore(t)
+	logs, requests := connect(t, store)
+	dispatchEach(t, store, logs, actions.Increment.With(1), actions.Increment.With(2), actions.Increment.With(4))
+
+	lifted := travel(t, requests, logs, command{Type: "TOGGLE_ACTION", ID: 2})
+	checkComputedStates(t, lifted, 0, 1, 1, 5)
+	if len(lifted.SkippedActionIDs) != 1 || lifted.SkippedActionIDs[0] != 2 {
+		t.Fatalf("the skipped actions are %v, want [2]", lifted.SkippedActionIDs)
+	}
+	if state := store.GetStateOf("counter"); state != 5 {
+		t.Fatalf("the state is %v, want 5", state)
+	}
+
+	lifted = travel(t, requests, logs, command{Type: "TOGGLE_ACTION", ID: 2})
+	checkComputedStates(t, lifted, 0, 1, 3, 7)
+	if state := store.GetStateOf("counter"); state != 7 || len(lifted.SkippedActionIDs) != 0 {
+		t.Fatalf("the state is %v with the skipped actions %v, want 7 without skipped actions", state, lifted.SkippedActionIDs)
+	}
+}
+
+func TestBridgeSweepsTheSkippedActions(t *testing.T) {
+	store, actions := newCounterStore(t)
+	logs, requests := connect(t, store)
+	dispatchEach(t, store, logs, actions.Increment.With(1), actions.Increment.With(2), actions.Increment.With(4))
+	travel(t, requests, logs, command{Type: "TOGGLE_ACTION", ID: 2})
+
+	lifted := travel(t, requests, logs, command{Type: "SWEEP"})
+	checkComputedStates(t, lifted, 0, 1, 5)
+	if len(lifted.SkippedActionIDs) != 0 || lifted.CurrentStateIndex != 2 {
+		t.Fatalf("the skipped actions are %v at the index %v, want none at the index 2", lifted.SkippedActionIDs, lifted.CurrentStateIndex)
+	}
+	if state := store.GetStateOf("counter"); state != 5 {
+		t.Fatalf("the state is %v, want 5", state)
+	}
+}
+
+func TestBridgeCommitsRollsBackAndResets(t *testing.T) {
+	store, actions := newCounterStore(t)
+	logs, requests := connect(t, store)
+	dispatchEach(t, store, logs, actions.Increment.With(1), actions.Increment.With(2))
+
+	lifted := travel(t, requests, logs, command{Type: "COMMIT"})
+	checkComputedStates(t, lifted, 3)
+	dispatchEach(t, store, logs, actions.Increment.With(4))
+
+	lifted = travel(t, requests, logs, command{Type: "ROLLBACK"})
+	checkComputedStates(t, lifted, 3)
+	if state := store.GetStateOf("counter"); state != 3 {
+		t.Fatalf("the state after the rollback is %v, want 3", state)
+	}
+
+	lifted = travel(t, requests, logs, command{Type: "RESET"})
+	checkComputedStates(t, lifted, 0)
+	if state := store.GetStateOf("counter"); state != 0 {
+		t.Fatalf("the state after the reset is %v, want 0", state)
+	}
+}
+
+func TestBridgeExportsAndImportsTheSession(t *testing.T) {
+	store, actions := newCounterStore(t)
+	logs, requests := connect(t, store)
+	dispatchEach(t, store, logs, actions.Increment.With(1), actions.Increment.With(2))
+
+	requests <- request{Type: "EXPORT"}
+	exported := receive(t, logs, "EXPORT")
+	if want := `[{"type":"counter/Increment","payload":1},{"type":"counter/Increment","payload":2}]`; exported.Payload != want {
+		t.Fatalf("the exported actions are %v, want %v", exported.Payload, want)
+	}
+	requests <- request{Type: "UPDATE"}
+	session := receive(t, logs, "STATE").Payload
+	travel(t, requests, logs, command{Type: "RESET"})
+
+	requests <- request{Type: "IMPORT", State: json.RawMessage(session)}
+	lifted := receiveState(t, logs)
+	checkComputedStates(t, lifted, 0, 1, 3)
+	if state := store.GetStateOf("counter"); state != 3 || lifted.CurrentStateIndex != 2 {
+		t.Fatalf("the imported state is %v at the index %v, want 3 at the index 2", state, lifted.CurrentStateIndex)
+	}
+}
+
+func TestWebSocketRejectsOversizedFrames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws, err := acceptWebSocket(w, r)
+		if err != nil {
+			return
+		}
+		defer ws.conn.Close()
+		ws.conn.Write([]byte{0x80 | opText, 127, 0x7F, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF})
+		ws.reader.ReadByte()
+	}))
+	defer server.Close()
+
+	ws, err := dialWebSocket("ws"+strings.TrimPrefix(server.URL, "http"), 5*time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	if _, err := ws.ReadMessage(); err == nil || !strings.Contains(err.Error(), "exceeds the maximum size") {
+		t.Fatalf("the oversized frame has not been rejected: %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package devtools
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/janmbaco/go-redux/src"
+)
+
+const (
+	initAction    = "@@INIT"
+	performAction = "PERFORM_ACTION"
+)
+
+type action struct {
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+type liftedAction struct {
+	Type      string `json:"type"`
+	Action    action `json:"action"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+type computedState struct {
+	State json.RawMessage `json:"state"`
+}
+
+type liftedState struct {
+	ActionsByID       map[int]liftedAction `json:"actionsById"`
+	ComputedStates    []computedState      `json:"computedStates"`
+	CommittedState    json.RawMessage      `json:"committedState"`
+	CurrentStateIndex int                  `json:"currentStateIndex"`
+	NextActionID      int                  `json:"nextActionId"`
+	SkippedActionIDs  []int                `json:"skippedActionIds"`
+	StagedActionIDs   []int                `json:"stagedActionIds"`
+	IsLocked          bool                 `json:"isLocked"`
+	IsPaused          bool                 `json:"isPaused"`
+}
+
+type record struct {
+	entry redux.ActionLogEntry
+	state json.RawMessage
+}
+
+type pendingRecord struct {
+	record
+	captured bool
+	err      error
+}
+
+func toAction(entry redux.ActionLogEntry) action {
+	return action{Type: entry.Selector + "/" + entry.Action, Payload: entry.Payload}
+}
+
+func toLiftedAction(entry redux.ActionLogEntry) liftedAction {
+	return liftedAction{Type: performAction, Action: toAction(entry), Timestamp: entry.Timestamp.UnixMilli()}
+}
+
+func toEntry(action action) (redux.ActionLogEntry, error) {
+	separator := strings.LastIndex(action.Type, "/")
+	if separator <= 0 || separator == len(action.Type)-1 {
+		return redux.ActionLogEntry{}, fmt.Errorf("the type of the action '%v' must be 'selector/action'", action.Type)
+	}
+	return redux.ActionLogEntry{Selector: action.Type[:separator], Action: action.Type[separator+1:], Payload: action.Payload, Timestamp: time.Now()}, nil
+}
+
+func unmarshalEncoded(raw json.RawMessage, value interface{}) error {
+	if len(raw) > 0 && raw[0] == '"' {
+		var encoded string
+		if err := json.Unmarshal(raw, &encoded); err != nil {
+			return err
+		}
+		raw = json.RawMessage(encoded)
+	}
+	return json.Unmarshal(raw, value)
+}
@@ -0,0 +1,120 @@
+package devtools
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type packet struct {
+	Event string          `json:"event,omitempty"`
+	Data  json.RawMessage `json:"data,omitempty"`
+	CID   int             `json:"cid,omitempty"`
+	RID   int             `json:"rid,omitempty"`
+	Error json.RawMessage `json:"error,omitempty"`
+}
+
+type publication struct {
+	Channel string          `json:"channel"`
+	Data    json.RawMessage `json:"data"`
+}
+
+type handshake struct {
+	ID string `json:"id"`
+}
+
+type socketCluster struct {
+	ws  *webSocket
+	id  string
+	cid int
+}
+
+func dialSocketCluster(rawURL string, timeout time.Duration) (*socketCluster, error) {
+	ws, err := dialWebSocket(rawURL, timeout)
+	if err != nil {
+		return nil, err
+	}
+	sc := &socketCluster{ws: ws}
+	data, err := sc.call("#handshake", map[string]interface{}{"authToken": nil})
+	if err != nil {
+		ws.Close()
+		return nil, err
+	}
+	var result handshake
+	if err := json.Unmarshal(data, &result); err != nil {
+		ws.Close()
+		return nil, err
+	}
+	sc.id = result.ID
+	return sc, nil
+}
+
+func (sc *socketCluster) call(event string, data interface{}) (json.RawMessage, error) {
+	sc.cid++
+	cid := sc.cid
+	if err := sc.write(packet{Event: event, CID: cid}, data); err != nil {
+		return nil, err
+	}
+	for {
+		response, err := sc.read()
+		if err != nil {
+			return nil, err
+		}
+		if response.RID != cid {
+			continue
+		}
+		if len(response.Error) > 0 && string(response.Error) != "null" {
+			return nil, fmt.Errorf("the event '%v' has failed: %s", event, response.Error)
+		}
+		return response.Data, nil
+	}
+}
+
+func (sc *socketCluster) emit(event string, data interface{}) error {
+	return sc.write(packet{Event: event}, data)
+}
+
+func (sc *socketCluster) subscribe(channel string) error {
+	return sc.emit("#subscribe", map[string]string{"channel": channel})
+}
+
+func (sc *socketCluster) read() (packet, error) {
+	for {
+		message, err := sc.ws.ReadMessage()
+		if err != nil {
+			return packet{}, err
+		}
+		switch message {
+		case "#1":
+			err = sc.ws.WriteMessage("#2")
+		case "":
+			err = sc.ws.WriteMessage("")
+		default:
+			var result packet
+			if err := json.Unmarshal([]byte(message), &result); err != nil {
+				return packet{}, err
+			}
+			return result, nil
+		}
+		if err != nil {
+			return packet{}, err
+		}
+	}
+}
+
+func (sc *socketCluster) write(message packet, data interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	message.Data = raw
+	raw, err = json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	return sc.ws.WriteMessage(string(raw))
+}
+
+func (sc *socketCluster) Close() error {
+	return sc.ws.Close()
+}
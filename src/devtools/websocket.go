@@ -0,0 +1,244 @@
+package devtools
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xA
+
+	webSocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+	maxMessageSize = 16 << 20
+)
+
+var errConnectionClosed = errors.New("the connection is closed")
+
+type webSocket struct {
+	conn       net.Conn
+	reader     *bufio.Reader
+	writeMutex sync.Mutex
+	closeOnce  sync.Once
+}
+
+func dialWebSocket(rawURL string, timeout time.Duration) (*webSocket, error) {
+	location, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	address := location.Host
+	if location.Port() == "" {
+		switch location.Scheme {
+		case "ws":
+			address = net.JoinHostPort(location.Hostname(), "80")
+		case "wss":
+			address = net.JoinHostPort(location.Hostname(), "443")
+		}
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	var conn net.Conn
+	switch location.Scheme {
+	case "ws":
+		conn, err = dialer.Dial("tcp", address)
+	case "wss":
+		conn, err = tls.DialWithDialer(dialer, "tcp", address, &tls.Config{ServerName: location.Hostname()})
+	default:
+		return nil, fmt.Errorf("the scheme '%v' is not supported", location.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ws := &webSocket{conn: conn, reader: bufio.NewReader(conn)}
+	if err := ws.handshake(location, timeout); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return ws, nil
+}
+
+func (ws *webSocket) handshake(location *url.URL, timeout time.Duration) error {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	key := base64.StdEncoding.EncodeToString(nonce)
+
+	request := &http.Request{
+		Method:     http.MethodGet,
+		URL:        &url.URL{Path: location.Path, RawQuery: location.RawQuery},
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header: http.Header{
+			"Upgrade":               {"websocket"},
+			"Connection":            {"Upgrade"},
+			"Sec-WebSocket-Key":     {key},
+			"Sec-WebSocket-Version": {"13"},
+		},
+		Host: location.Host,
+	}
+	if request.URL.Path == "" {
+		request.URL.Path = "/"
+	}
+	if timeout > 0 {
+		ws.conn.SetDeadline(time.Now().Add(timeout))
+		defer ws.conn.SetDeadline(time.Time{})
+	}
+	if err := request.Write(ws.conn); err != nil {
+		return err
+	}
+
+	response, err := http.ReadResponse(ws.reader, request)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusSwitchingProtocols {
+		return fmt.Errorf("the server refused the websocket upgrade: %v", response.Status)
+	}
+	hash := sha1.Sum([]byte(key + webSocketGUID))
+	if response.Header.Get("Sec-WebSocket-Accept") != base64.StdEncoding.EncodeToString(hash[:]) {
+		return errors.New("the server sent an invalid Sec-WebSocket-Accept")
+	}
+	return nil
+}
+
+func (ws *webSocket) ReadMessage() (string, error) {
+	message := make([]byte, 0)
+	for {
+		fin, opcode, payload, err := ws.readFrame()
+		if err != nil {
+			ws.Close()
+			return "", err
+		}
+		switch opcode {
+		case opPing:
+			if err := ws.writeFrame(opPong, payload); err != nil {
+				return "", err
+			}
+		case opPong:
+		case opClose:
+			ws.Close()
+			return "", errConnectionClosed
+		case opText, opBinary, opContinuation:
+			if len(message)+len(payload) > maxMessageSize {
+				ws.Close()
+				return "", fmt.Errorf("the message exceeds the maximum size of %v bytes", maxMessageSize)
+			}
+			message = append(message, payload...)
+			if fin {
+				return string(message), nil
+			}
+		default:
+			return "", fmt.Errorf("the opcode '%v' is not supported", opcode)
+		}
+	}
+}
+
+func (ws *webSocket) WriteMessage(message string) error {
+	return ws.writeFrame(opText, []byte(message))
+}
+
+func (ws *webSocket) Close() error {
+	var err error
+	ws.closeOnce.Do(func() {
+		ws.writeFrame(opClose, nil)
+		err = ws.conn.Close()
+	})
+	return err
+}
+
+func (ws *webSocket) readFrame() (bool, byte, []byte, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(ws.reader, header); err != nil {
+		return false, 0, nil, err
+	}
+	fin := header[0]&0x80 != 0
+	opcode := header[0] & 0x0F
+	masked := header[1]&0x80 != 0
+	length := uint64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		extended := make([]byte, 2)
+		if _, err := io.ReadFull(ws.reader, extended); err != nil {
+			return false, 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(extended))
+	case 127:
+		extended := make([]byte, 8)
+		if _, err := io.ReadFull(ws.reader, extended); err != nil {
+			return false, 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(extended)
+	}
+	if length > maxMessageSize {
+		return false, 0, nil, fmt.Errorf("the frame of %v bytes exceeds the maximum size of %v bytes", length, maxMessageSize)
+	}
+	mask := make([]byte, 4)
+	if masked {
+		if _, err := io.ReadFull(ws.reader, mask); err != nil {
+			return false, 0, nil, err
+		}
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(ws.reader, payload); err != nil {
+		return false, 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return fin, opcode, payload, nil
+}
+
+func (ws *webSocket) writeFrame(opcode byte, payload []byte) error {
+	frame := make([]byte, 0, len(payload)+14)
+	frame = append(frame, 0x80|opcode)
+	length := len(payload)
+	switch {
+	case length < 126:
+		frame = append(frame, 0x80|byte(length))
+	case length <= 0xFFFF:
+		extended := make([]byte, 2)
+		binary.BigEndian.PutUint16(extended, uint16(length))
+		frame = append(append(frame, 0x80|126), extended...)
+	default:
+		extended := make([]byte, 8)
+		binary.BigEndian.PutUint64(extended, uint64(length))
+		frame = append(append(frame, 0x80|127), extended...)
+	}
+	mask := make([]byte, 4)
+	if _, err := rand.Read(mask); err != nil {
+		return err
+	}
+	frame = append(frame, mask...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+
+	ws.writeMutex.Lock()
+	defer ws.writeMutex.Unlock()
+	_, err := ws.conn.Write(frame)
+	return err
+}
@@ -0,0 +1,197 @@
+package redux
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type Effect func(ctx context.Context, result DispatchResult) error
+
+type effectKind uint8
+
+const (
+	takeEvery effectKind = iota
+	takeLatest
+	takeLeading
+	debounce
+)
+
+type EffectPolicy struct {
+	kind effectKind
+	wait time.Duration
+}
+
+var (
+	TakeEvery   = EffectPolicy{kind: takeEvery}
+	TakeLatest  = EffectPolicy{kind: takeLatest}
+	TakeLeading = EffectPolicy{kind: takeLeading}
+)
+
+func Debounce(wait time.Duration) EffectPolicy {
+	return EffectPolicy{kind: debounce, wait: wait}
+}
+
+type effects struct {
+	mutex      sync.Mutex
+	ctx        context.Context
+	cancel     context.CancelFunc
+	runners    map[Action][]*effectRunner
+	catchError func(func())
+}
+
+type effectRunner struct {
+	*effects
+	mutex      sync.Mutex
+	effect     *Effect
+	policy     EffectPolicy
+	running    bool
+	generation uint64
+	cancel     context.CancelFunc
+	timer      *time.Timer
+}
+
+func newEffects(parent context.Context, catchError func(func())) *effects {
+	ctx, cancel := context.WithCancel(parent)
+	return &effects{
+		ctx:        ctx,
+		cancel:     cancel,
+		runners:    make(map[Action][]*effectRunner),
+		catchError: catchError,
+	}
+}
+
+func (e *effects) add(action Action, policy EffectPolicy, effect *Effect) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	action = action.GetOrigin()
+	for _, runner := range e.runners[action] {
+		if runner.effect == effect {
+			panic(newStoreError(EffectAlreadyAddedError, "The effect is already added for this action!"))
+		}
+	}
+	e.runners[action] = append(e.runners[action], &effectRunner{effects: e, effect: effect, policy: policy})
+}
+
+func (e *effects) remove(action Action, effect *Effect) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	action = action.GetOrigin()
+	runners := e.runners[action]
+	for i, runner := range runners {
+		if runner.effect == effect {
+			runner.stop()
+			e.runners[action] = append(runners[:i:i], runners[i+1:]...)
+			break
+		}
+	}
+	if len(e.runners[action]) == 0 {
+		delete(e.runners, action)
+	}
+}
+
+func (e *effects) run(result DispatchResult) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if e.ctx.Err() != nil {
+		return
+	}
+	for _, runner := range e.runners[result.Action.GetOrigin()] {
+		runner.run(result)
+	}
+}
+
+func (e *effects) shutdown() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.cancel()
+	for _, runners := range e.runners {
+		for _, runner := range runners {
+			runner.stop()
+		}
+	}
+}
+
+func (r *effectRunner) run(result DispatchResult) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	switch r.policy.kind {
+	case takeLatest:
+		if r.cancel != nil {
+			r.cancel()
+		}
+		ctx, cancel := context.WithCancel(r.ctx)
+		r.generation++
+		r.cancel = cancel
+		generation := r.generation
+		go func() {
+			defer r.release(generation)
+			r.call(ctx, result)
+		}()
+	case takeLeading:
+		if r.running {
+			return
+		}
+		r.running = true
+		go func() {
+			defer r.release(0)
+			r.call(r.ctx, result)
+		}()
+	case debounce:
+		if r.timer != nil {
+			r.timer.Stop()
+		}
+		r.timer = time.AfterFunc(r.policy.wait, func() {
+			r.call(r.ctx, result)
+		})
+	default:
+		go r.call(r.ctx, result)
+	}
+}
+
+func (r *effectRunner) release(generation uint64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.running = false
+	if generation != 0 && generation == r.generation {
+		r.cancel()
+		r.cancel = nil
+	}
+}
+
+func (r *effectRunner) stop() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+}
+
+func (r *effectRunner) call(ctx context.Context, result DispatchResult) {
+	ctx, cancel := mergeContext(result.Action.GetContext(), ctx)
+	defer cancel()
+	if ctx.Err() != nil {
+		return
+	}
+	r.catchError(func() {
+		if err := (*r.effect)(ctx, result); err != nil {
+			panic(newStoreErrorFrom(EffectError, err))
+		}
+	})
+}
+
+func mergeContext(parent context.Context, other context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	if other.Done() == nil {
+		return ctx, cancel
+	}
+	go func() {
+		select {
+		case <-other.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
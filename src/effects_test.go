@@ -0,0 +1,62 @@
+package redux_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/janmbaco/go-redux/src"
+)
+
+func TestEffectErrorsAreSentToTheErrorHandler(t *testing.T) {
+	for name, policy := range map[string]redux.EffectPolicy{
+		"takeEvery": redux.TakeEvery,
+		"debounce":  redux.Debounce(time.Millisecond),
+	} {
+		t.Run(name, func(t *testing.T) {
+			store, actions := newCounterStore(t)
+			errs := make(chan error, 1)
+			store.SetErrorHandler(func(err error) {
+				errs <- err
+			})
+			failure := errors.New("failure")
+			effect := redux.Effect(func(ctx context.Context, result redux.DispatchResult) error {
+				return failure
+			})
+			store.AddEffect(actions.Increment, policy, &effect)
+			store.Dispatch(actions.Increment.With(1))
+
+			select {
+			case err := <-errs:
+				if !errors.Is(err, redux.EffectError) || !errors.Is(err, failure) {
+					t.Fatalf("the error is %v, want an EffectError wrapping %v", err, failure)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("the error of the effect was not handled")
+			}
+		})
+	}
+}
+
+func TestEffectPanicsAreSentToTheErrorHandler(t *testing.T) {
+	store, actions := newCounterStore(t)
+	errs := make(chan error, 1)
+	store.SetErrorHandler(func(err error) {
+		errs <- err
+	})
+	effect := redux.Effect(func(ctx context.Context, result redux.DispatchResult) error {
+		panic("failure")
+	})
+	store.AddEffect(actions.Increment, redux.TakeLeading, &effect)
+	store.Dispatch(actions.Increment.With(1))
+
+	select {
+	case err := <-errs:
+		if err == nil {
+			t.Fatal("the error is nil")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the panic of the effect was not handled")
+	}
+}
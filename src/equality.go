@@ -0,0 +1,133 @@
+package redux
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+)
+
+type EqualityFunc func(prev interface{}, next interface{}) bool
+
+type Versioned interface {
+	GetVersion() uint64
+}
+
+func DeepEquality(prev interface{}, next interface{}) bool {
+	return reflect.DeepEqual(prev, next)
+}
+
+func PointerEquality(prev interface{}, next interface{}) bool {
+	prevValue, nextValue := reflect.ValueOf(prev), reflect.ValueOf(next)
+	if !prevValue.IsValid() || !nextValue.IsValid() {
+		return prevValue.IsValid() == nextValue.IsValid()
+	}
+	if prevValue.Type() != nextValue.Type() {
+		return false
+	}
+	switch prevValue.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return prevValue.Pointer() == nextValue.Pointer()
+	case reflect.Slice:
+		return prevValue.Pointer() == nextValue.Pointer() && prevValue.Len() == nextValue.Len()
+	}
+	return prevValue.Type().Comparable() && prev == next
+}
+
+func VersionEquality(prev interface{}, next interface{}) bool {
+	prevVersioned, isPrevVersioned := prev.(Versioned)
+	nextVersioned, isNextVersioned := next.(Versioned)
+	return isPrevVersioned && isNextVersioned && prevVersioned.GetVersion() == nextVersioned.GetVersion()
+}
+
+func HashEquality(prev interface{}, next interface{}) bool {
+	return structuralHash(prev) == structuralHash(next)
+}
+
+func structuralHash(state interface{}) uint64 {
+	hasher := fnv.New64a()
+	writeHash(hasher, reflect.ValueOf(state), make(map[uintptr]bool))
+	return hasher.Sum64()
+}
+
+func writeHash(hasher hash.Hash64, value reflect.Value, visited map[uintptr]bool) {
+	buffer := make([]byte, 8)
+	writeUint := func(n uint64) {
+		binary.LittleEndian.PutUint64(buffer, n)
+		hasher.Write(buffer)
+	}
+	if !value.IsValid() {
+		writeUint(0)
+		return
+	}
+	hasher.Write([]byte(value.Type().String()))
+	switch value.Kind() {
+	case reflect.Bool:
+		if value.Bool() {
+			writeUint(1)
+		} else {
+			writeUint(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint(uint64(value.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeUint(math.Float64bits(value.Float()))
+	case reflect.Complex64, reflect.Complex128:
+		writeUint(math.Float64bits(real(value.Complex())))
+		writeUint(math.Float64bits(imag(value.Complex())))
+	case reflect.String:
+		writeUint(uint64(value.Len()))
+		hasher.Write([]byte(value.String()))
+	case reflect.Array, reflect.Slice:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			writeUint(0)
+			return
+		}
+		writeUint(uint64(value.Len()))
+		for i := 0; i < value.Len(); i++ {
+			writeHash(hasher, value.Index(i), visited)
+		}
+	case reflect.Map:
+		if value.IsNil() {
+			writeUint(0)
+			return
+		}
+		writeUint(uint64(value.Len()))
+		var sum uint64
+		iterator := value.MapRange()
+		for iterator.Next() {
+			entry := fnv.New64a()
+			writeHash(entry, iterator.Key(), visited)
+			writeHash(entry, iterator.Value(), visited)
+			sum += entry.Sum64()
+		}
+		writeUint(sum)
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			writeHash(hasher, value.Field(i), visited)
+		}
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			writeUint(0)
+			return
+		}
+		if value.Kind() == reflect.Ptr {
+			if visited[value.Pointer()] {
+				writeUint(1)
+				return
+			}
+			visited[value.Pointer()] = true
+			defer delete(visited, value.Pointer())
+		}
+		writeHash(hasher, value.Elem(), visited)
+	default:
+		if value.IsNil() {
+			writeUint(0)
+		} else {
+			writeUint(uint64(value.Pointer()))
+		}
+	}
+}
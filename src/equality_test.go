@@ -0,0 +1,62 @@
+package redux_test
+
+import (
+	"testing"
+
+	"github.com/janmbaco/go-redux/src"
+	"github.com/janmbaco/go-redux/src/ioc/resolver"
+)
+
+type catalog struct {
+	Products []string
+}
+
+type catalogActions struct {
+	Keep redux.Action
+	Add  redux.Action
+}
+
+func TestPointerEqualityNotifiesAReducerReturningItsInputOnlyWithoutReadOnlyState(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		readOnly bool
+		want     int
+	}{
+		{name: "read-only state", readOnly: true, want: 0},
+		{name: "cloned state", readOnly: false, want: 1},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			actions := &catalogActions{}
+			builder := resolver.GetBusinessParamBuilder()
+			builder.SetInitialState(&catalog{})
+			builder.SetActions(actions)
+			builder.On(actions.Keep, func(state *catalog) *catalog {
+				return state
+			})
+			builder.On(actions.Add, func(state *catalog, product string) *catalog {
+				return &catalog{Products: append(append([]string(nil), state.Products...), product)}
+			})
+			builder.SetEqualityFunc(redux.PointerEquality)
+			builder.SetReadOnlyState(test.readOnly)
+			builder.SetSelector("catalog")
+			store := resolver.GetStore()
+			store.AddReducer(builder.GetBusinessParam())
+			defer store.Shutdown()
+
+			notifications := 0
+			onChange := func(redux.Change) {
+				notifications++
+			}
+			store.SubscribeToChanges("catalog", &onChange)
+
+			store.Dispatch(actions.Keep)
+			if notifications != test.want {
+				t.Fatalf("%v changes were notified for a reducer returning its input, want %v", notifications, test.want)
+			}
+			store.Dispatch(actions.Add.With("book"))
+			if notifications != test.want+1 {
+				t.Fatalf("%v changes were notified for a new state, want %v", notifications, test.want+1)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package events
+
+import "reflect"
+
+type ChangeEvent struct {
+	Change     interface{}
+	TypeOfFunc reflect.Type
+}
+
+func (e *ChangeEvent) GetEventArgs() interface{} {
+	return e.Change
+}
+
+func (*ChangeEvent) HasEventArgs() bool {
+	return true
+}
+
+func (*ChangeEvent) StopPropagation() bool {
+	return false
+}
+
+func (*ChangeEvent) IsParallelPropagation() bool {
+	return true
+}
+
+func (e *ChangeEvent) GetTypeOfFunc() reflect.Type {
+	return e.TypeOfFunc
+}
@@ -0,0 +1,28 @@
+package events
+
+import (
+	"reflect"
+
+	"github.com/janmbaco/go-infrastructure/eventsmanager"
+)
+
+type ChangeEventHandler struct {
+	subscriptions eventsmanager.Subscriptions
+	typeOfFunc    reflect.Type
+}
+
+func NewChangeEventHandler(subscriptions eventsmanager.Subscriptions, typeOfFunc reflect.Type) *ChangeEventHandler {
+	return &ChangeEventHandler{subscriptions: subscriptions, typeOfFunc: typeOfFunc}
+}
+
+func (m *ChangeEventHandler) Subscribe(subscription interface{}) {
+	m.subscriptions.Add(&ChangeEvent{TypeOfFunc: m.typeOfFunc}, subscription)
+}
+
+func (m *ChangeEventHandler) UnSubscribe(subscription interface{}) {
+	m.subscriptions.Remove(&ChangeEvent{TypeOfFunc: m.typeOfFunc}, subscription)
+}
+
+func (m *ChangeEventHandler) NewEvent(change interface{}) *ChangeEvent {
+	return &ChangeEvent{Change: change, TypeOfFunc: m.typeOfFunc}
+}
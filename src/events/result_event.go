@@ -0,0 +1,28 @@
+package events
+
+import "reflect"
+
+type ResultEvent struct {
+	Result     interface{}
+	TypeOfFunc reflect.Type
+}
+
+func (e *ResultEvent) GetEventArgs() interface{} {
+	return e.Result
+}
+
+func (*ResultEvent) HasEventArgs() bool {
+	return true
+}
+
+func (*ResultEvent) StopPropagation() bool {
+	return false
+}
+
+func (*ResultEvent) IsParallelPropagation() bool {
+	return true
+}
+
+func (e *ResultEvent) GetTypeOfFunc() reflect.Type {
+	return e.TypeOfFunc
+}
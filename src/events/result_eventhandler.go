@@ -0,0 +1,28 @@
+package events
+
+import (
+	"reflect"
+
+	"github.com/janmbaco/go-infrastructure/eventsmanager"
+)
+
+type ResultEventHandler struct {
+	subscriptions eventsmanager.Subscriptions
+	typeOfFunc    reflect.Type
+}
+
+func NewResultEventHandler(subscriptions eventsmanager.Subscriptions, typeOfFunc reflect.Type) *ResultEventHandler {
+	return &ResultEventHandler{subscriptions: subscriptions, typeOfFunc: typeOfFunc}
+}
+
+func (m *ResultEventHandler) Subscribe(subscription interface{}) {
+	m.subscriptions.Add(&ResultEvent{TypeOfFunc: m.typeOfFunc}, subscription)
+}
+
+func (m *ResultEventHandler) UnSubscribe(subscription interface{}) {
+	m.subscriptions.Remove(&ResultEvent{TypeOfFunc: m.typeOfFunc}, subscription)
+}
+
+func (m *ResultEventHandler) NewEvent(result interface{}) *ResultEvent {
+	return &ResultEvent{Result: result, TypeOfFunc: m.typeOfFunc}
+}
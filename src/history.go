@@ -0,0 +1,112 @@
+package redux
+
+import (
+	"time"
+)
+
+type HistoryActions struct {
+	Undo         Action
+	Redo         Action
+	JumpTo       Action
+	ClearHistory Action
+}
+
+type HistoryParam struct {
+	Limit       int
+	Actions     *HistoryActions
+	Filter      []Action
+	GroupWindow time.Duration
+}
+
+type history struct {
+	param      *HistoryParam
+	filter     map[Action]bool
+	past       []interface{}
+	future     []interface{}
+	lastRecord time.Time
+}
+
+func newHistory(param *HistoryParam) *history {
+	result := &history{param: param}
+	if len(param.Filter) > 0 {
+		result.filter = make(map[Action]bool)
+		for _, action := range param.Filter {
+			result.filter[action.GetOrigin()] = true
+		}
+	}
+	return result
+}
+
+func (h *history) getActions() []Action {
+	return []Action{h.param.Actions.Undo, h.param.Actions.Redo, h.param.Actions.JumpTo, h.param.Actions.ClearHistory}
+}
+
+func (h *history) isHistoryAction(action Action) bool {
+	for _, historyAction := range h.getActions() {
+		if action.GetOrigin() == historyAction.GetOrigin() {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *history) record(prevState interface{}, action Action) {
+	h.future = nil
+	if h.filter != nil && !h.filter[action.GetOrigin()] {
+		return
+	}
+	now := time.Now()
+	grouped := h.param.GroupWindow > 0 && len(h.past) > 0 && now.Sub(h.lastRecord) < h.param.GroupWindow
+	h.lastRecord = now
+	if grouped {
+		return
+	}
+	h.past = append(h.past, prevState)
+	if len(h.past) > h.param.Limit {
+		h.past = h.past[len(h.past)-h.param.Limit:]
+	}
+}
+
+func (h *history) travel(action Action, present interface{}) interface{} {
+	switch action.GetOrigin() {
+	case h.param.Actions.Undo.GetOrigin():
+		return h.undo(present)
+	case h.param.Actions.Redo.GetOrigin():
+		return h.redo(present)
+	case h.param.Actions.JumpTo.GetOrigin():
+		steps := int(action.GetPayload().Int())
+		for ; steps < 0; steps++ {
+			present = h.undo(present)
+		}
+		for ; steps > 0; steps-- {
+			present = h.redo(present)
+		}
+		return present
+	default:
+		h.past = nil
+		h.future = nil
+		return present
+	}
+}
+
+func (h *history) undo(present interface{}) interface{} {
+	if len(h.past) == 0 {
+		return present
+	}
+	result := h.past[len(h.past)-1]
+	h.past = h.past[:len(h.past)-1]
+	h.future = append([]interface{}{present}, h.future...)
+	h.lastRecord = time.Time{}
+	return result
+}
+
+func (h *history) redo(present interface{}) interface{} {
+	if len(h.future) == 0 {
+		return present
+	}
+	result := h.future[0]
+	h.future = h.future[1:]
+	h.past = append(h.past, present)
+	h.lastRecord = time.Time{}
+	return result
+}
@@ -0,0 +1,161 @@
+package redux_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/janmbaco/go-redux/src"
+	"github.com/janmbaco/go-redux/src/ioc/resolver"
+)
+
+func newHistoryStore(t *testing.T, limit int, options ...func(redux.BusinesParamBuilder, *counterActions)) (redux.Store, *counterActions, *redux.HistoryActions) {
+	t.Helper()
+	actions := &counterActions{}
+	historyActions := &redux.HistoryActions{}
+	builder := resolver.GetBusinessParamBuilder()
+	builder.SetInitialState(0)
+	builder.SetActions(actions)
+	builder.On(actions.Increment, func(state int, payload int) int {
+		return state + payload
+	})
+	builder.On(actions.Reset, func(state int) int {
+		return 0
+	})
+	builder.On(actions.Fail, func(state int) int {
+		panic("failure")
+	})
+	builder.WithHistory(limit, historyActions)
+	for _, option := range options {
+		option(builder, actions)
+	}
+	builder.SetSelector("counter")
+	store := resolver.GetStore()
+	store.AddReducer(builder.GetBusinessParam())
+	t.Cleanup(store.Shutdown)
+	return store, actions, historyActions
+}
+
+func checkCounter(t *testing.T, store redux.Store, want int) {
+	t.Helper()
+	if state := store.GetStateOf("counter"); state != want {
+		t.Fatalf("the state is %v, want %v", state, want)
+	}
+}
+
+func TestUndoAndRedo(t *testing.T) {
+	store, actions, history := newHistoryStore(t, 10)
+	store.Dispatch(actions.Increment.With(1))
+	store.Dispatch(actions.Increment.With(2))
+
+	store.Dispatch(history.Undo)
+	checkCounter(t, store, 1)
+	store.Dispatch(history.Undo)
+	checkCounter(t, store, 0)
+	store.Dispatch(history.Undo)
+	checkCounter(t, store, 0)
+	store.Dispatch(history.Redo)
+	checkCounter(t, store, 1)
+	store.Dispatch(history.Redo)
+	checkCounter(t, store, 3)
+	store.Dispatch(history.Redo)
+	checkCounter(t, store, 3)
+}
+
+func TestNewActionDiscardsTheFuture(t *testing.T) {
+	store, actions, history := newHistoryStore(t, 10)
+	store.Dispatch(actions.Increment.With(1))
+	store.Dispatch(actions.Increment.With(2))
+	store.Dispatch(history.Undo)
+
+	store.Dispatch(actions.Increment.With(5))
+	store.Dispatch(history.Redo)
+	checkCounter(t, store, 6)
+	store.Dispatch(history.Undo)
+	checkCounter(t, store, 1)
+}
+
+func TestJumpTo(t *testing.T) {
+	store, actions, history := newHistoryStore(t, 10)
+	for i := 0; i < 4; i++ {
+		store.Dispatch(actions.Increment.With(1))
+	}
+
+	store.Dispatch(history.JumpTo.With(-3))
+	checkCounter(t, store, 1)
+	store.Dispatch(history.JumpTo.With(2))
+	checkCounter(t, store, 3)
+	store.Dispatch(history.JumpTo.With(-10))
+	checkCounter(t, store, 0)
+	store.Dispatch(history.JumpTo.With(10))
+	checkCounter(t, store, 4)
+}
+
+func TestClearHistory(t *testing.T) {
+	store, actions, history := newHistoryStore(t, 10)
+	store.Dispatch(actions.Increment.With(1))
+	store.Dispatch(actions.Increment.With(1))
+	store.Dispatch(history.Undo)
+
+	store.Dispatch(history.ClearHistory)
+	checkCounter(t, store, 1)
+	store.Dispatch(history.Undo)
+	checkCounter(t, store, 1)
+	store.Dispatch(history.Redo)
+	checkCounter(t, store, 1)
+}
+
+func TestHistoryKeepsOnlyTheLimit(t *testing.T) {
+	store, actions, history := newHistoryStore(t, 2)
+	for i := 0; i < 4; i++ {
+		store.Dispatch(actions.Increment.With(1))
+	}
+
+	store.Dispatch(history.JumpTo.With(-4))
+	checkCounter(t, store, 2)
+}
+
+func TestGroupHistoryRecordsTheActionsOfTheWindowAsOneStep(t *testing.T) {
+	store, actions, history := newHistoryStore(t, 10, func(builder redux.BusinesParamBuilder, _ *counterActions) {
+		builder.GroupHistory(time.Hour)
+	})
+	store.Dispatch(actions.Increment.With(1))
+	store.Dispatch(actions.Increment.With(1))
+	store.Dispatch(actions.Increment.With(1))
+
+	store.Dispatch(history.Undo)
+	checkCounter(t, store, 0)
+	store.Dispatch(history.Redo)
+	checkCounter(t, store, 3)
+	store.Dispatch(actions.Increment.With(1))
+	store.Dispatch(history.Undo)
+	checkCounter(t, store, 3)
+}
+
+func TestFilterHistoryRecordsOnlyTheFilteredActions(t *testing.T) {
+	store, actions, history := newHistoryStore(t, 10, func(builder redux.BusinesParamBuilder, actions *counterActions) {
+		builder.FilterHistory(actions.Increment)
+	})
+	store.Dispatch(actions.Increment.With(1))
+	store.Dispatch(actions.Increment.With(2))
+	store.Dispatch(actions.Reset)
+
+	store.Dispatch(history.Undo)
+	checkCounter(t, store, 1)
+	store.Dispatch(history.Undo)
+	checkCounter(t, store, 0)
+}
+
+func TestFilteredActionDiscardsTheFuture(t *testing.T) {
+	store, actions, history := newHistoryStore(t, 10, func(builder redux.BusinesParamBuilder, actions *counterActions) {
+		builder.FilterHistory(actions.Increment)
+	})
+	store.Dispatch(actions.Increment.With(1))
+	store.Dispatch(actions.Increment.With(2))
+	store.Dispatch(history.Undo)
+
+	store.Dispatch(actions.Reset)
+	store.Dispatch(history.Redo)
+	checkCounter(t, store, 0)
+	store.Dispatch(history.Undo)
+	checkCounter(t, store, 0)
+}
@@ -0,0 +1,38 @@
+package httphandler
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+type Authenticator func(r *http.Request) error
+
+type unauthorizedError struct {
+	challenge string
+}
+
+func (e *unauthorizedError) Error() string {
+	return "the request is not authorized"
+}
+
+func BearerToken(token string) Authenticator {
+	return func(r *http.Request) error {
+		const prefix = "Bearer "
+		header := r.Header.Get("Authorization")
+		if !strings.HasPrefix(header, prefix) || subtle.ConstantTimeCompare([]byte(header[len(prefix):]), []byte(token)) != 1 {
+			return &unauthorizedError{challenge: "Bearer"}
+		}
+		return nil
+	}
+}
+
+func BasicAuth(user string, password string) Authenticator {
+	return func(r *http.Request) error {
+		requestUser, requestPassword, ok := r.BasicAuth()
+		if !ok || subtle.ConstantTimeCompare([]byte(requestUser), []byte(user))&subtle.ConstantTimeCompare([]byte(requestPassword), []byte(password)) != 1 {
+			return &unauthorizedError{challenge: `Basic realm="go-redux", charset="UTF-8"`}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,84 @@
+package httphandler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/janmbaco/go-redux/src"
+)
+
+type event struct {
+	Selector string      `json:"selector"`
+	Action   string      `json:"action,omitempty"`
+	Seq      uint64      `json:"seq"`
+	Prev     interface{} `json:"prev"`
+	Next     interface{} `json:"next"`
+}
+
+func (h *handler) events(w http.ResponseWriter, r *http.Request) {
+	flusher, isFlusher := w.(http.Flusher)
+	if !isFlusher {
+		writeError(w, http.StatusInternalServerError, errors.New("the response does not support streaming"))
+		return
+	}
+
+	changes := make(chan redux.Change, h.options.EventBuffer)
+	overflow := make(chan struct{})
+	var overflowOnce sync.Once
+	subscription := func(change redux.Change) {
+		select {
+		case changes <- change:
+		default:
+			overflowOnce.Do(func() {
+				close(overflow)
+			})
+		}
+	}
+	selector := r.URL.Query().Get("selector")
+	if selector == "" {
+		h.store.SubscribeChanges(&subscription)
+		defer h.store.UnsubscribeChanges(&subscription)
+	} else {
+		if err := try(func() {
+			h.store.SubscribeToChanges(selector, &subscription)
+		}); err != nil {
+			writeError(w, statusOf(err), err)
+			return
+		}
+		defer h.store.UnsubscribeFromChanges(selector, &subscription)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-overflow:
+			return
+		case change := <-changes:
+			data, err := json.Marshal(toEvent(change))
+			if err != nil {
+				data, _ = json.Marshal(errorResponse{Error: err.Error()})
+				fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+			} else {
+				fmt.Fprintf(w, "id: %v\nevent: change\ndata: %s\n\n", change.Seq, data)
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func toEvent(change redux.Change) event {
+	result := event{Selector: change.Selector, Seq: change.Seq, Prev: change.Prev, Next: change.Next}
+	if change.Action != nil {
+		result.Action = change.Action.GetType()
+	}
+	return result
+}
@@ -0,0 +1,210 @@
+package httphandler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/janmbaco/go-infrastructure/errors/errorschecker"
+	"github.com/janmbaco/go-redux/src"
+)
+
+const (
+	DefaultEventBuffer = 64
+	maxPayloadSize     = 1 << 20
+)
+
+type Options struct {
+	ReadOnly      bool
+	Authenticator Authenticator
+	EventBuffer   int
+}
+
+type actionInfo struct {
+	Name        string `json:"name"`
+	PayloadType string `json:"payloadType,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+type handler struct {
+	store   redux.Store
+	options Options
+}
+
+func NewHandler(store redux.Store, options Options) http.Handler {
+	errorschecker.CheckNilParameter(map[string]interface{}{"store": store})
+	if options.EventBuffer <= 0 {
+		options.EventBuffer = DefaultEventBuffer
+	}
+	return &handler{store: store, options: options}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.options.Authenticator != nil {
+		if err := h.options.Authenticator(r); err != nil {
+			var unauthorized *unauthorizedError
+			if errors.As(err, &unauthorized) {
+				w.Header().Set("WWW-Authenticate", unauthorized.challenge)
+			}
+			writeError(w, http.StatusUnauthorized, err)
+			return
+		}
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case segments[0] == "state" && len(segments) == 1:
+		if allow(w, r, http.MethodGet) {
+			h.getState(w)
+		}
+	case segments[0] == "state" && len(segments) == 2:
+		if allow(w, r, http.MethodGet) {
+			h.getStateOf(w, segments[1])
+		}
+	case segments[0] == "actions" && len(segments) == 1:
+		if allow(w, r, http.MethodGet) {
+			h.getActions(w)
+		}
+	case segments[0] == "dispatch" && len(segments) == 3:
+		if allow(w, r, http.MethodPost) {
+			h.dispatch(w, r, segments[1], segments[2])
+		}
+	case segments[0] == "events" && len(segments) == 1:
+		if allow(w, r, http.MethodGet) {
+			h.events(w, r)
+		}
+	default:
+		writeError(w, http.StatusNotFound, fmt.Errorf("the path '%v' does not exist", r.URL.Path))
+	}
+}
+
+func (h *handler) getState(w http.ResponseWriter) {
+	var state interface{}
+	if err := try(func() {
+		state = h.store.GetState()
+	}); err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, state)
+}
+
+func (h *handler) getStateOf(w http.ResponseWriter, selector string) {
+	var state interface{}
+	if err := try(func() {
+		state = h.store.GetStateOf(selector)
+	}); err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, state)
+}
+
+func (h *handler) getActions(w http.ResponseWriter) {
+	result := make(map[string][]actionInfo)
+	for selector, actionsObject := range h.store.GetActionsObjects() {
+		names := append([]string(nil), actionsObject.GetActionsNames()...)
+		sort.Strings(names)
+		actions := make([]actionInfo, 0, len(names))
+		for _, name := range names {
+			info := actionInfo{Name: name}
+			if payloadType := actionsObject.GetActionByName(name).GetPayloadType(); payloadType != nil {
+				info.PayloadType = payloadType.String()
+			}
+			actions = append(actions, info)
+		}
+		result[selector] = actions
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *handler) dispatch(w http.ResponseWriter, r *http.Request, selector string, name string) {
+	if h.options.ReadOnly {
+		writeError(w, http.StatusForbidden, errors.New("the store is exposed in read-only mode"))
+		return
+	}
+	if _, exists := h.store.GetActionsObjects()[selector]; !exists {
+		writeError(w, http.StatusNotFound, fmt.Errorf("there is not any slice with the selector '%v'", selector))
+		return
+	}
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPayloadSize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	action, err := h.store.DecodeAction(redux.ActionLogEntry{Selector: selector, Action: name, Payload: bytes.TrimSpace(payload)})
+	if err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+	if err := h.store.TryDispatchContext(r.Context(), action); err != nil {
+		writeError(w, statusOf(err), err)
+		return
+	}
+	h.getStateOf(w, selector)
+}
+
+func allow(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method == method {
+		return true
+	}
+	w.Header().Set("Allow", method)
+	writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("the method '%v' is not allowed", r.Method))
+	return false
+}
+
+func statusOf(err error) int {
+	switch {
+	case errors.Is(err, redux.AnyStateBySelectorError):
+		return http.StatusNotFound
+	case errors.Is(err, redux.EmptySelectorError),
+		errors.Is(err, redux.PayloadTypeError),
+		errors.Is(err, redux.ActionLogError),
+		errors.Is(err, redux.AnyReducerForThisActionError):
+		return http.StatusBadRequest
+	case errors.Is(err, redux.ContextDoneError):
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	body, err := json.Marshal(value)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	body, _ := json.Marshal(errorResponse{Error: err.Error()})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+func try(fn func()) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if recoveredError, isError := recovered.(error); isError {
+				err = recoveredError
+			} else {
+				err = fmt.Errorf("%v", recovered)
+			}
+		}
+	}()
+	fn()
+	return nil
+}
@@ -0,0 +1,204 @@
+package httphandler
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/janmbaco/go-infrastructure/dependencyinjection/static"
+	"github.com/janmbaco/go-redux/src"
+	"github.com/janmbaco/go-redux/src/ioc/resolver"
+)
+
+type counterActions struct {
+	Increment redux.Action
+}
+
+type list struct {
+	Items []string
+}
+
+type listActions struct {
+	Mutate redux.Action
+}
+
+func init() {
+	static.Container.Register().AsType(new(redux.Store), redux.NewStore, nil)
+}
+
+func newStore(t *testing.T) redux.Store {
+	t.Helper()
+	counter := &counterActions{}
+	builder := resolver.GetBusinessParamBuilder()
+	builder.SetInitialState(0)
+	builder.SetActions(counter)
+	builder.On(counter.Increment, func(state int, payload int) int {
+		return state + payload
+	})
+	builder.WithHistory(10, &redux.HistoryActions{})
+	builder.SetSelector("counter")
+	store := resolver.GetStore()
+	store.AddReducer(builder.GetBusinessParam())
+
+	actions := &listActions{}
+	builder = resolver.GetBusinessParamBuilder()
+	builder.SetInitialState(&list{Items: []string{"pen"}})
+	builder.SetActions(actions)
+	builder.On(actions.Mutate, func(state *list) *list {
+		state.Items[0] = "book"
+		return state
+	})
+	builder.SetCloner(redux.ReadOnlyCloner)
+	builder.SetSelector("list")
+	store.AddReducer(builder.GetBusinessParam())
+	store.SetDevMode(true)
+	t.Cleanup(store.Shutdown)
+	return store
+}
+
+func serve(handler http.Handler, method string, path string, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return recorder
+}
+
+func TestDispatch(t *testing.T) {
+	handler := NewHandler(newStore(t), Options{})
+
+	for _, test := range []struct {
+		path   string
+		body   string
+		status int
+		state  string
+	}{
+		{path: "/dispatch/counter/Increment", body: "2", status: http.StatusOK, state: "2"},
+		{path: "/dispatch/counter/Undo", status: http.StatusOK, state: "0"},
+		{path: "/dispatch/counter/Redo", status: http.StatusOK, state: "2"},
+		{path: "/dispatch/counter/Increment", body: `"two"`, status: http.StatusBadRequest},
+		{path: "/dispatch/counter/Decrement", status: http.StatusBadRequest},
+		{path: "/dispatch/total/Increment", status: http.StatusNotFound},
+		{path: "/dispatch/list/Mutate", status: http.StatusInternalServerError},
+	} {
+		recorder := serve(handler, http.MethodPost, test.path, test.body)
+		if recorder.Code != test.status {
+			t.Fatalf("%v responded %v, want %v: %v", test.path, recorder.Code, test.status, recorder.Body)
+		}
+		if test.state != "" && recorder.Body.String() != test.state {
+			t.Fatalf("%v responded the state %v, want %v", test.path, recorder.Body, test.state)
+		}
+	}
+}
+
+func TestGetActions(t *testing.T) {
+	recorder := serve(NewHandler(newStore(t), Options{}), http.MethodGet, "/actions", "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("the response is %v, want %v", recorder.Code, http.StatusOK)
+	}
+	var actions map[string][]actionInfo
+	if err := json.Unmarshal(recorder.Body.Bytes(), &actions); err != nil {
+		t.Fatal(err)
+	}
+	if mutate := actions["list"]; len(mutate) != 1 || mutate[0] != (actionInfo{Name: "Mutate"}) {
+		t.Fatalf("the actions of the list are %v, want only Mutate without payload", mutate)
+	}
+	for _, info := range actions["counter"] {
+		if info.Name == "Increment" {
+			if info.PayloadType != "int" {
+				t.Fatalf("the payload of Increment is %q, want int", info.PayloadType)
+			}
+			return
+		}
+	}
+	t.Fatalf("the actions of the counter are %v, want Increment", actions["counter"])
+}
+
+func TestReadOnlyRejectsTheDispatches(t *testing.T) {
+	handler := NewHandler(newStore(t), Options{ReadOnly: true})
+
+	if recorder := serve(handler, http.MethodPost, "/dispatch/counter/Increment", "2"); recorder.Code != http.StatusForbidden {
+		t.Fatalf("the dispatch responded %v, want %v", recorder.Code, http.StatusForbidden)
+	}
+	recorder := serve(handler, http.MethodGet, "/state/counter", "")
+	if recorder.Code != http.StatusOK || recorder.Body.String() != "0" {
+		t.Fatalf("the state responded %v with %v, want %v with 0", recorder.Code, recorder.Body, http.StatusOK)
+	}
+}
+
+func TestEventsStreamTheChangesOfASlice(t *testing.T) {
+	store := newStore(t)
+	server := httptest.NewServer(NewHandler(store, Options{}))
+	defer server.Close()
+
+	if response, err := http.Get(server.URL + "/events?selector=total"); err != nil || response.StatusCode != http.StatusNotFound {
+		t.Fatalf("the events of an unknown slice responded %v and %v, want %v", response, err, http.StatusNotFound)
+	}
+	response, err := http.Get(server.URL + "/events?selector=counter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	if contentType := response.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Fatalf("the content type is %q, want text/event-stream", contentType)
+	}
+
+	store.Dispatch(store.GetActionsObjects()["counter"].GetActionByName("Increment").With(2))
+
+	reader := bufio.NewReader(response.Body)
+	lines := make([]string, 0, 3)
+	for len(lines) < 3 {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, strings.TrimSuffix(line, "\n"))
+	}
+	if lines[0] != "id: 1" || lines[1] != "event: change" {
+		t.Fatalf("the event is %v, want the change 1", lines)
+	}
+	var received event
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(lines[2], "data: ")), &received); err != nil {
+		t.Fatal(err)
+	}
+	if received.Selector != "counter" || received.Action != "Increment" || received.Prev != 0.0 || received.Next != 2.0 {
+		t.Fatalf("the event is %+v, want the increment of the counter from 0 to 2", received)
+	}
+}
+
+func TestStatusOf(t *testing.T) {
+	for _, test := range []struct {
+		err    error
+		status int
+	}{
+		{err: redux.AnyStateBySelectorError, status: http.StatusNotFound},
+		{err: redux.ActionLogError, status: http.StatusBadRequest},
+		{err: redux.MutationError, status: http.StatusInternalServerError},
+		{err: redux.ContextDoneError, status: http.StatusServiceUnavailable},
+		{err: redux.PersistError, status: http.StatusInternalServerError},
+	} {
+		if status := statusOf(test.err); status != test.status {
+			t.Fatalf("the status of %v is %v, want %v", test.err, status, test.status)
+		}
+	}
+}
+
+func TestAuthenticationChallenges(t *testing.T) {
+	store := newStore(t)
+	for _, test := range []struct {
+		authenticator Authenticator
+		challenge     string
+	}{
+		{authenticator: BasicAuth("user", "password"), challenge: `Basic realm="go-redux", charset="UTF-8"`},
+		{authenticator: BearerToken("token"), challenge: "Bearer"},
+	} {
+		recorder := serve(NewHandler(store, Options{Authenticator: test.authenticator}), http.MethodGet, "/state", "")
+		if recorder.Code != http.StatusUnauthorized {
+			t.Fatalf("the response is %v, want %v", recorder.Code, http.StatusUnauthorized)
+		}
+		if challenge := recorder.Header().Get("WWW-Authenticate"); challenge != test.challenge {
+			t.Fatalf("the challenge is %q, want %q", challenge, test.challenge)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package jsonpatch
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var tokenEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func Diff(prev interface{}, next interface{}) (Patch, error) {
+	prevDoc, err := normalize(prev)
+	if err != nil {
+		return nil, err
+	}
+	nextDoc, err := normalize(next)
+	if err != nil {
+		return nil, err
+	}
+	return diff("", prevDoc, nextDoc, make(Patch, 0)), nil
+}
+
+func diff(path string, prev interface{}, next interface{}, patch Patch) Patch {
+	switch prevContainer := prev.(type) {
+	case map[string]interface{}:
+		if nextContainer, ok := next.(map[string]interface{}); ok {
+			return diffObjects(path, prevContainer, nextContainer, patch)
+		}
+	case []interface{}:
+		if nextContainer, ok := next.([]interface{}); ok {
+			return diffArrays(path, prevContainer, nextContainer, patch)
+		}
+	}
+	if reflect.DeepEqual(prev, next) {
+		return patch
+	}
+	return append(patch, Operation{Op: Replace, Path: path, Value: next})
+}
+
+func diffObjects(path string, prev map[string]interface{}, next map[string]interface{}, patch Patch) Patch {
+	keys := make([]string, 0, len(prev)+len(next))
+	for key := range prev {
+		keys = append(keys, key)
+	}
+	for key := range next {
+		if _, exists := prev[key]; !exists {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		childPath := path + "/" + tokenEscaper.Replace(key)
+		prevValue, inPrev := prev[key]
+		nextValue, inNext := next[key]
+		switch {
+		case !inNext:
+			patch = append(patch, Operation{Op: Remove, Path: childPath})
+		case !inPrev:
+			patch = append(patch, Operation{Op: Add, Path: childPath, Value: nextValue})
+		default:
+			patch = diff(childPath, prevValue, nextValue, patch)
+		}
+	}
+	return patch
+}
+
+func diffArrays(path string, prev []interface{}, next []interface{}, patch Patch) Patch {
+	common := len(prev)
+	if len(next) < common {
+		common = len(next)
+	}
+	for i := 0; i < common; i++ {
+		patch = diff(path+"/"+strconv.Itoa(i), prev[i], next[i], patch)
+	}
+	for i := len(prev) - 1; i >= common; i-- {
+		patch = append(patch, Operation{Op: Remove, Path: path + "/" + strconv.Itoa(i)})
+	}
+	for i := common; i < len(next); i++ {
+		patch = append(patch, Operation{Op: Add, Path: path + "/" + strconv.Itoa(i), Value: next[i]})
+	}
+	return patch
+}
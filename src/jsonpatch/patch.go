@@ -0,0 +1,276 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	Add     = "add"
+	Remove  = "remove"
+	Replace = "replace"
+	Move    = "move"
+	Copy    = "copy"
+	Test    = "test"
+)
+
+type Operation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+type Patch []Operation
+
+func (o Operation) MarshalJSON() ([]byte, error) {
+	fields := map[string]interface{}{"op": o.Op, "path": o.Path}
+	if o.Op == Move || o.Op == Copy {
+		fields["from"] = o.From
+	}
+	if o.Op == Add || o.Op == Replace || o.Op == Test {
+		fields["value"] = o.Value
+	}
+	return json.Marshal(fields)
+}
+
+func Apply(document []byte, patch Patch) ([]byte, error) {
+	var doc interface{}
+	if err := json.Unmarshal(document, &doc); err != nil {
+		return nil, err
+	}
+	for _, operation := range patch {
+		var err error
+		if doc, err = applyOperation(doc, operation); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(doc)
+}
+
+func applyOperation(doc interface{}, operation Operation) (interface{}, error) {
+	path, err := parsePointer(operation.Path)
+	if err != nil {
+		return nil, err
+	}
+	switch operation.Op {
+	case Add:
+		value, err := normalize(operation.Value)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	case Remove:
+		return remove(doc, path)
+	case Replace:
+		value, err := normalize(operation.Value)
+		if err != nil {
+			return nil, err
+		}
+		return replace(doc, path, value)
+	case Move, Copy:
+		from, err := parsePointer(operation.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := get(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if operation.Op == Move {
+			if strings.HasPrefix(operation.Path+"/", operation.From+"/") && operation.Path != operation.From {
+				return nil, fmt.Errorf("cannot move '%v' into one of its children", operation.From)
+			}
+			if doc, err = remove(doc, from); err != nil {
+				return nil, err
+			}
+		} else if value, err = normalize(value); err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	case Test:
+		value, err := normalize(operation.Value)
+		if err != nil {
+			return nil, err
+		}
+		actual, err := get(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(value, actual) {
+			return nil, fmt.Errorf("the test of the path '%v' has failed", operation.Path)
+		}
+		return doc, nil
+	}
+	return nil, fmt.Errorf("the operation '%v' is not supported", operation.Op)
+}
+
+func add(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	key := path[len(path)-1]
+	return update(doc, path[:len(path)-1], func(parent interface{}) (interface{}, error) {
+		switch container := parent.(type) {
+		case map[string]interface{}:
+			container[key] = value
+			return container, nil
+		case []interface{}:
+			i := len(container)
+			if key != "-" {
+				var err error
+				if i, err = parseIndex(key, len(container)+1); err != nil {
+					return nil, err
+				}
+			}
+			result := make([]interface{}, 0, len(container)+1)
+			result = append(result, container[:i]...)
+			result = append(result, value)
+			return append(result, container[i:]...), nil
+		}
+		return nil, fmt.Errorf("the path '/%v' is not a container", strings.Join(path[:len(path)-1], "/"))
+	})
+}
+
+func remove(doc interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("cannot remove the whole document")
+	}
+	key := path[len(path)-1]
+	return update(doc, path[:len(path)-1], func(parent interface{}) (interface{}, error) {
+		switch container := parent.(type) {
+		case map[string]interface{}:
+			if _, exists := container[key]; !exists {
+				return nil, fmt.Errorf("the member '%v' does not exist", key)
+			}
+			delete(container, key)
+			return container, nil
+		case []interface{}:
+			i, err := parseIndex(key, len(container))
+			if err != nil {
+				return nil, err
+			}
+			result := make([]interface{}, 0, len(container)-1)
+			result = append(result, container[:i]...)
+			return append(result, container[i+1:]...), nil
+		}
+		return nil, fmt.Errorf("the path '/%v' is not a container", strings.Join(path[:len(path)-1], "/"))
+	})
+}
+
+func replace(doc interface{}, path []string, value interface{}) (interface{}, error) {
+	if _, err := get(doc, path); err != nil {
+		return nil, err
+	}
+	if len(path) == 0 {
+		return value, nil
+	}
+	key := path[len(path)-1]
+	return update(doc, path[:len(path)-1], func(parent interface{}) (interface{}, error) {
+		switch container := parent.(type) {
+		case map[string]interface{}:
+			container[key] = value
+		case []interface{}:
+			i, _ := parseIndex(key, len(container))
+			container[i] = value
+		}
+		return parent, nil
+	})
+}
+
+func get(doc interface{}, path []string) (interface{}, error) {
+	for _, key := range path {
+		switch container := doc.(type) {
+		case map[string]interface{}:
+			value, exists := container[key]
+			if !exists {
+				return nil, fmt.Errorf("the member '%v' does not exist", key)
+			}
+			doc = value
+		case []interface{}:
+			i, err := parseIndex(key, len(container))
+			if err != nil {
+				return nil, err
+			}
+			doc = container[i]
+		default:
+			return nil, fmt.Errorf("the member '%v' does not exist", key)
+		}
+	}
+	return doc, nil
+}
+
+func update(node interface{}, path []string, fn func(interface{}) (interface{}, error)) (interface{}, error) {
+	if len(path) == 0 {
+		return fn(node)
+	}
+	switch container := node.(type) {
+	case map[string]interface{}:
+		child, exists := container[path[0]]
+		if !exists {
+			return nil, fmt.Errorf("the member '%v' does not exist", path[0])
+		}
+		child, err := update(child, path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		container[path[0]] = child
+		return container, nil
+	case []interface{}:
+		i, err := parseIndex(path[0], len(container))
+		if err != nil {
+			return nil, err
+		}
+		child, err := update(container[i], path[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		container[i] = child
+		return container, nil
+	}
+	return nil, fmt.Errorf("the member '%v' does not exist", path[0])
+}
+
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("the pointer '%v' must start with '/'", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens, nil
+}
+
+func parseIndex(token string, length int) (int, error) {
+	if token == "" || strings.Trim(token, "0123456789") != "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("the index '%v' is not valid", token)
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("the index '%v' is not valid", token)
+	}
+	return i, checkIndex(token, i, length)
+}
+
+func checkIndex(token string, i int, length int) error {
+	if i < 0 || i >= length {
+		return fmt.Errorf("the index '%v' is out of range", token)
+	}
+	return nil
+}
+
+func normalize(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var result interface{}
+	return result, json.Unmarshal(data, &result)
+}
@@ -0,0 +1,167 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func checkDocument(t *testing.T, got []byte, want string) {
+	t.Helper()
+	var gotDoc, wantDoc interface{}
+	if err := json.Unmarshal(got, &gotDoc); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantDoc); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gotDoc, wantDoc) {
+		t.Fatalf("the document is %s, want %s", got, want)
+	}
+}
+
+func TestApply(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		document  string
+		operation Operation
+		want      string
+	}{
+		{name: "add a member", document: `{"a":1}`, operation: Operation{Op: Add, Path: "/b", Value: 2}, want: `{"a":1,"b":2}`},
+		{name: "add replaces a member", document: `{"a":1}`, operation: Operation{Op: Add, Path: "/a", Value: 2}, want: `{"a":2}`},
+		{name: "add an element", document: `{"a":[1,3]}`, operation: Operation{Op: Add, Path: "/a/1", Value: 2}, want: `{"a":[1,2,3]}`},
+		{name: "add at the length", document: `{"a":[1]}`, operation: Operation{Op: Add, Path: "/a/1", Value: 2}, want: `{"a":[1,2]}`},
+		{name: "add at the end", document: `{"a":[1]}`, operation: Operation{Op: Add, Path: "/a/-", Value: 2}, want: `{"a":[1,2]}`},
+		{name: "add the document", document: `{"a":1}`, operation: Operation{Op: Add, Path: "", Value: []int{1}}, want: `[1]`},
+		{name: "remove a member", document: `{"a":1,"b":2}`, operation: Operation{Op: Remove, Path: "/a"}, want: `{"b":2}`},
+		{name: "remove an element", document: `[1,2,3]`, operation: Operation{Op: Remove, Path: "/1"}, want: `[1,3]`},
+		{name: "replace a member", document: `{"a":{"b":1}}`, operation: Operation{Op: Replace, Path: "/a/b", Value: "c"}, want: `{"a":{"b":"c"}}`},
+		{name: "replace an element", document: `[1,2]`, operation: Operation{Op: Replace, Path: "/0", Value: 3}, want: `[3,2]`},
+		{name: "replace the document", document: `1`, operation: Operation{Op: Replace, Path: "", Value: 2}, want: `2`},
+		{name: "move a member", document: `{"a":{"b":1},"c":2}`, operation: Operation{Op: Move, From: "/a/b", Path: "/c"}, want: `{"a":{},"c":1}`},
+		{name: "move an element", document: `[1,2,3]`, operation: Operation{Op: Move, From: "/0", Path: "/-"}, want: `[2,3,1]`},
+		{name: "copy a member", document: `{"a":{"b":1}}`, operation: Operation{Op: Copy, From: "/a", Path: "/c"}, want: `{"a":{"b":1},"c":{"b":1}}`},
+		{name: "test a value", document: `{"a":[1,{"b":"c"}]}`, operation: Operation{Op: Test, Path: "/a/1", Value: map[string]string{"b": "c"}}, want: `{"a":[1,{"b":"c"}]}`},
+		{name: "escaped slash", document: `{"a/b":1}`, operation: Operation{Op: Replace, Path: "/a~1b", Value: 2}, want: `{"a/b":2}`},
+		{name: "escaped tilde", document: `{"m~n":1}`, operation: Operation{Op: Remove, Path: "/m~0n"}, want: `{}`},
+		{name: "escaped tilde before one", document: `{"~1":1}`, operation: Operation{Op: Replace, Path: "/~01", Value: 2}, want: `{"~1":2}`},
+		{name: "empty member", document: `{"":1}`, operation: Operation{Op: Replace, Path: "/", Value: 2}, want: `{"":2}`},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			document, err := Apply([]byte(test.document), Patch{test.operation})
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkDocument(t, document, test.want)
+		})
+	}
+}
+
+func TestApplyRejects(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		document  string
+		operation Operation
+	}{
+		{name: "add out of range", document: `[1]`, operation: Operation{Op: Add, Path: "/2", Value: 2}},
+		{name: "add to a missing parent", document: `{}`, operation: Operation{Op: Add, Path: "/a/b", Value: 2}},
+		{name: "add to a value", document: `{"a":1}`, operation: Operation{Op: Add, Path: "/a/b", Value: 2}},
+		{name: "remove a missing member", document: `{}`, operation: Operation{Op: Remove, Path: "/a"}},
+		{name: "remove out of range", document: `[1]`, operation: Operation{Op: Remove, Path: "/1"}},
+		{name: "remove the end", document: `[1]`, operation: Operation{Op: Remove, Path: "/-"}},
+		{name: "remove the document", document: `{}`, operation: Operation{Op: Remove, Path: ""}},
+		{name: "replace a missing member", document: `{}`, operation: Operation{Op: Replace, Path: "/a", Value: 1}},
+		{name: "move into a child", document: `{"a":{}}`, operation: Operation{Op: Move, From: "/a", Path: "/a/b"}},
+		{name: "move a missing member", document: `{}`, operation: Operation{Op: Move, From: "/a", Path: "/b"}},
+		{name: "copy a missing member", document: `{}`, operation: Operation{Op: Copy, From: "/a", Path: "/b"}},
+		{name: "failed test", document: `{"a":1}`, operation: Operation{Op: Test, Path: "/a", Value: 2}},
+		{name: "failed test of the type", document: `{"a":1}`, operation: Operation{Op: Test, Path: "/a", Value: "1"}},
+		{name: "test a missing member", document: `{}`, operation: Operation{Op: Test, Path: "/a", Value: 1}},
+		{name: "index with a plus sign", document: `[1,2]`, operation: Operation{Op: Replace, Path: "/+1", Value: 3}},
+		{name: "index with a minus sign", document: `[1,2]`, operation: Operation{Op: Replace, Path: "/-1", Value: 3}},
+		{name: "index with a leading zero", document: `[1,2]`, operation: Operation{Op: Replace, Path: "/01", Value: 3}},
+		{name: "index with spaces", document: `[1,2]`, operation: Operation{Op: Replace, Path: "/ 1", Value: 3}},
+		{name: "empty index", document: `[1,2]`, operation: Operation{Op: Replace, Path: "/", Value: 3}},
+		{name: "pointer without slash", document: `{"a":1}`, operation: Operation{Op: Replace, Path: "a", Value: 2}},
+		{name: "unsupported operation", document: `{}`, operation: Operation{Op: "merge", Path: ""}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if document, err := Apply([]byte(test.document), Patch{test.operation}); err == nil {
+				t.Fatalf("the operation has been applied: %s", document)
+			}
+		})
+	}
+}
+
+func TestApplyIsAtomicPerCall(t *testing.T) {
+	document := []byte(`{"a":1}`)
+	patch := Patch{{Op: Replace, Path: "/a", Value: 2}, {Op: Test, Path: "/a", Value: 1}}
+	if _, err := Apply(document, patch); err == nil {
+		t.Fatal("the patch with a failed test has been applied")
+	}
+	checkDocument(t, document, `{"a":1}`)
+}
+
+type order struct {
+	ID    int
+	Items []string
+	Tags  map[string]string
+	Notes *string
+}
+
+func TestDiffAppliedToThePreviousStateGivesTheNextState(t *testing.T) {
+	note := "gift"
+	for _, test := range []struct {
+		name string
+		prev interface{}
+		next interface{}
+	}{
+		{name: "equal", prev: order{ID: 1}, next: order{ID: 1}},
+		{name: "members", prev: order{ID: 1, Tags: map[string]string{"a": "1", "b": "2"}}, next: order{ID: 2, Tags: map[string]string{"b": "3", "c": "4"}}},
+		{name: "escaped keys", prev: map[string]int{"a/b": 1, "m~n": 2}, next: map[string]int{"a/b": 3, "~1": 4}},
+		{name: "grown array", prev: order{Items: []string{"pen"}}, next: order{Items: []string{"book", "pen", "ink"}}},
+		{name: "shrunk array", prev: order{Items: []string{"pen", "book", "ink"}}, next: order{Items: []string{"ink"}}},
+		{name: "nil to value", prev: order{}, next: order{Notes: &note, Items: []string{}}},
+		{name: "types", prev: map[string]interface{}{"a": []int{1}}, next: map[string]interface{}{"a": map[string]int{"0": 1}}},
+		{name: "document", prev: []int{1, 2}, next: "replaced"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			patch, err := Diff(test.prev, test.next)
+			if err != nil {
+				t.Fatal(err)
+			}
+			prev, _ := json.Marshal(test.prev)
+			next, _ := json.Marshal(test.next)
+			document, err := Apply(prev, patch)
+			if err != nil {
+				t.Fatalf("the patch %v failed: %v", patch, err)
+			}
+			checkDocument(t, document, string(next))
+		})
+	}
+}
+
+func TestDiffOfEqualStatesIsEmpty(t *testing.T) {
+	patch, err := Diff(order{ID: 1, Items: []string{"pen"}}, order{ID: 1, Items: []string{"pen"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(patch) != 0 {
+		t.Fatalf("the patch is %v, want it empty", patch)
+	}
+}
+
+func TestOperationsAreMarshalledWithTheirFields(t *testing.T) {
+	data, err := json.Marshal(Patch{
+		{Op: Remove, Path: "/a"},
+		{Op: Add, Path: "/b", Value: nil},
+		{Op: Move, From: "/c", Path: "/d"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"op":"remove","path":"/a"},{"op":"add","path":"/b","value":null},{"from":"/c","op":"move","path":"/d"}]`
+	if string(data) != want {
+		t.Fatalf("the patch is marshalled as %s, want %s", data, want)
+	}
+}
@@ -0,0 +1,45 @@
+package redux
+
+import (
+	"reflect"
+
+	"github.com/janmbaco/go-infrastructure/errors/errorschecker"
+)
+
+type DispatchFunc func(action Action) DispatchResult
+
+type Middleware func(next DispatchFunc) DispatchFunc
+
+type SliceResult struct {
+	Selector  string
+	PrevState interface{}
+	NextState interface{}
+	Changed   bool
+}
+
+type DispatchResult struct {
+	Action Action
+	Seq    uint64
+	Slices []SliceResult
+}
+
+var resultFuncType = reflect.TypeOf(func(DispatchResult) {})
+
+func (s *store) SubscribeResults(fn *func(DispatchResult)) {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	errorschecker.CheckNilParameter(map[string]interface{}{"fn": fn})
+	s.results.Subscribe(fn)
+}
+
+func (s *store) UnsubscribeResults(fn *func(DispatchResult)) {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	errorschecker.CheckNilParameter(map[string]interface{}{"fn": fn})
+	s.results.UnSubscribe(fn)
+}
+
+func applyMiddlewares(middlewares []Middleware, dispatch DispatchFunc) DispatchFunc {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		dispatch = middlewares[i](dispatch)
+	}
+	return dispatch
+}
@@ -0,0 +1,108 @@
+package redux_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/janmbaco/go-redux/src"
+)
+
+func TestMiddlewaresRunInTheOrderTheyAreApplied(t *testing.T) {
+	store, actions := newCounterStore(t)
+	calls := make([]string, 0)
+	trace := func(name string) redux.Middleware {
+		return func(next redux.DispatchFunc) redux.DispatchFunc {
+			return func(action redux.Action) redux.DispatchResult {
+				calls = append(calls, name+" before")
+				result := next(action)
+				calls = append(calls, name+" after")
+				return result
+			}
+		}
+	}
+	store.ApplyMiddleware(trace("first"), trace("second"))
+	store.ApplyMiddleware(trace("third"))
+
+	store.Dispatch(actions.Increment.With(1))
+
+	want := []string{"first before", "second before", "third before", "third after", "second after", "first after"}
+	if len(calls) != len(want) {
+		t.Fatalf("the calls are %v, want %v", calls, want)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Fatalf("the calls are %v, want %v", calls, want)
+		}
+	}
+}
+
+func TestMiddlewareCanShortCircuitTheDispatch(t *testing.T) {
+	store, actions := newCounterStore(t)
+	reached := false
+	store.ApplyMiddleware(func(next redux.DispatchFunc) redux.DispatchFunc {
+		return func(action redux.Action) redux.DispatchResult {
+			if action.GetOrigin() == actions.Reset {
+				return redux.DispatchResult{Action: action}
+			}
+			return next(action)
+		}
+	}, func(next redux.DispatchFunc) redux.DispatchFunc {
+		return func(action redux.Action) redux.DispatchResult {
+			reached = true
+			return next(action)
+		}
+	})
+	notifications := 0
+	onChange := func(interface{}) {
+		notifications++
+	}
+	store.SubscribeTo("counter", &onChange)
+
+	store.Dispatch(actions.Increment.With(2))
+	reached = false
+	store.Dispatch(actions.Reset)
+
+	if reached {
+		t.Fatal("the middleware after the short-circuit has been called")
+	}
+	if state := store.GetStateOf("counter"); state != 2 {
+		t.Fatalf("the state is %v, want 2", state)
+	}
+	if notifications != 1 {
+		t.Fatalf("%v notifications, want 1", notifications)
+	}
+}
+
+func TestMiddlewareCanReplaceTheAction(t *testing.T) {
+	store, actions := newCounterStore(t)
+	var dispatched redux.Action
+	store.ApplyMiddleware(func(next redux.DispatchFunc) redux.DispatchFunc {
+		return func(action redux.Action) redux.DispatchResult {
+			if action.GetOrigin() == actions.Increment {
+				return next(actions.Increment.With(action.GetPayload().Interface().(int) * 10))
+			}
+			return next(action)
+		}
+	}, func(next redux.DispatchFunc) redux.DispatchFunc {
+		return func(action redux.Action) redux.DispatchResult {
+			dispatched = action
+			return next(action)
+		}
+	})
+
+	store.Dispatch(actions.Increment.With(2))
+
+	if state := store.GetStateOf("counter"); state != 20 {
+		t.Fatalf("the state is %v, want 20", state)
+	}
+	if payload := dispatched.GetPayload().Interface(); payload != 20 {
+		t.Fatalf("the next middleware received the payload %v, want 20", payload)
+	}
+}
+
+func TestApplyMiddlewareRejectsNil(t *testing.T) {
+	store, _ := newCounterStore(t)
+	if err := catch(func() { store.ApplyMiddleware(nil) }); !errors.Is(err, redux.NilMiddlewareError) {
+		t.Fatalf("the error is %v, want a NilMiddlewareError", err)
+	}
+}
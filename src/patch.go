@@ -0,0 +1,125 @@
+package redux
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/janmbaco/go-infrastructure/errors/errorschecker"
+	"github.com/janmbaco/go-redux/src/jsonpatch"
+)
+
+var PatchAction Action = &action{name: "@@PATCH", typ: reflect.TypeOf(jsonpatch.Patch{})}
+
+type patchSubscription struct {
+	selector string
+	fn       *func(Change, jsonpatch.Patch)
+}
+
+func (s *store) ApplyPatch(selector string, patch jsonpatch.Patch) {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	checkSelector(selector)
+	defer s.flush()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.enqueue(nil, false, s.applyPatch(selector, patch, true))
+}
+
+func (s *store) applyPatch(selector string, patch jsonpatch.Patch, logged bool) DispatchResult {
+	s.checkStateManager(selector)
+	if _, isComputed := s.computed[selector]; isComputed {
+		panic(newStoreError(PatchError, "The computed slices are read-only!"))
+	}
+
+	stateManagement := s.stateManagements[selector]
+	prevState := stateManagement.GetState()
+	if hasUnexportedFields(reflect.TypeOf(prevState), make(map[reflect.Type]bool)) {
+		panic(newStoreError(PatchError, fmt.Sprintf("The state of '%v' has unexported fields that would be lost by the patch!", selector)))
+	}
+	document, err := json.Marshal(prevState)
+	if err != nil {
+		panic(newStoreErrorFrom(PatchError, err))
+	}
+	if document, err = jsonpatch.Apply(document, patch); err != nil {
+		panic(newStoreErrorFrom(PatchError, err))
+	}
+	nextState := reflect.New(reflect.TypeOf(prevState))
+	if err := json.Unmarshal(document, nextState.Interface()); err != nil {
+		panic(newStoreErrorFrom(PatchError, err))
+	}
+
+	action := PatchAction.With(patch)
+	if logged {
+		s.writeToLog(s.newLogEntry(selector, action, s.seq+1))
+	}
+	s.seq++
+	result := DispatchResult{Action: action, Seq: s.seq, Slices: []SliceResult{{Selector: selector, PrevState: prevState}}}
+	if result.Slices[0].Changed = stateManagement.Update(nextState.Elem().Interface()); result.Slices[0].Changed {
+		s.versions[selector]++
+	}
+	result.Slices[0].NextState = stateManagement.GetState()
+	result.Slices = append(result.Slices, s.recompute(changedSelectors(result.Slices))...)
+	return result
+}
+
+func (s *store) SubscribeToPatches(selector string, fn *func(Change, jsonpatch.Patch)) {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	errorschecker.CheckNilParameter(map[string]interface{}{"fn": fn})
+	s.patchMutex.Lock()
+	defer s.patchMutex.Unlock()
+	key := patchSubscription{selector: selector, fn: fn}
+	if _, exists := s.patchSubscriptions[key]; exists {
+		return
+	}
+	onChange := func(change Change) {
+		defer s.errorDefer.TryThrowError(s.errorPipe)
+		patch, err := jsonpatch.Diff(change.Prev, change.Next)
+		if err != nil {
+			panic(newStoreErrorFrom(PatchError, err))
+		}
+		(*fn)(change, patch)
+	}
+	s.SubscribeToChanges(selector, &onChange)
+	s.patchSubscriptions[key] = &onChange
+}
+
+func (s *store) UnsubscribeFromPatches(selector string, fn *func(Change, jsonpatch.Patch)) {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	errorschecker.CheckNilParameter(map[string]interface{}{"fn": fn})
+	s.patchMutex.Lock()
+	defer s.patchMutex.Unlock()
+	key := patchSubscription{selector: selector, fn: fn}
+	if onChange, exists := s.patchSubscriptions[key]; exists {
+		s.UnsubscribeFromChanges(selector, onChange)
+		delete(s.patchSubscriptions, key)
+	}
+}
+
+var jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+func hasUnexportedFields(typ reflect.Type, visited map[reflect.Type]bool) bool {
+	if typ == nil || visited[typ] {
+		return false
+	}
+	visited[typ] = true
+	if typ.Implements(jsonMarshalerType) || reflect.PtrTo(typ).Implements(jsonMarshalerType) {
+		return false
+	}
+	switch typ.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return hasUnexportedFields(typ.Elem(), visited)
+	case reflect.Map:
+		return hasUnexportedFields(typ.Key(), visited) || hasUnexportedFields(typ.Elem(), visited)
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if field.PkgPath != "" && !field.Anonymous {
+				return true
+			}
+			if hasUnexportedFields(field.Type, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
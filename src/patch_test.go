@@ -0,0 +1,124 @@
+package redux_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/janmbaco/go-redux/src"
+	"github.com/janmbaco/go-redux/src/ioc/resolver"
+	"github.com/janmbaco/go-redux/src/jsonpatch"
+)
+
+func TestApplyPatchIsSequencedAndNotifiedWithThePatchAction(t *testing.T) {
+	store, actions := newCounterStore(t)
+	store.Dispatch(actions.Increment.With(1))
+	changes := make([]redux.Change, 0)
+	onChange := func(change redux.Change) {
+		changes = append(changes, change)
+	}
+	store.SubscribeChanges(&onChange)
+
+	patch := jsonpatch.Patch{{Op: jsonpatch.Replace, Path: "", Value: 5}}
+	store.ApplyPatch("counter", patch)
+	store.Dispatch(actions.Increment.With(1))
+
+	if len(changes) != 2 {
+		t.Fatalf("%v changes were notified, want 2", len(changes))
+	}
+	if changes[0].Action == nil || changes[0].Action.GetOrigin() != redux.PatchAction || changes[0].Seq != 2 || changes[0].Next != 5 {
+		t.Fatalf("the change of the patch is %+v, want the PatchAction with seq 2", changes[0])
+	}
+	if changes[1].Seq != 3 {
+		t.Fatalf("the seq of the next action is %v, want 3", changes[1].Seq)
+	}
+}
+
+func TestPatchesAreLoggedAndReplayed(t *testing.T) {
+	store, actions := newCounterStore(t)
+	log := redux.NewMemoryActionLog()
+	store.SetActionLog(log)
+	store.Dispatch(actions.Increment.With(1))
+	store.ApplyPatch("counter", jsonpatch.Patch{{Op: jsonpatch.Replace, Path: "", Value: 10}})
+	store.Dispatch(actions.Increment.With(1))
+
+	entries, _ := log.Entries(0)
+	if len(entries) != 3 || entries[1].Action != redux.PatchAction.GetType() {
+		t.Fatalf("the logged entries are %+v, want the patch in the second entry", entries)
+	}
+	store.Replay(log, 0)
+	if state := store.GetStateOf("counter"); state != 11 {
+		t.Fatalf("the replayed state is %v, want 11", state)
+	}
+}
+
+func TestDuplicatedPatchSubscriptionsAreIgnored(t *testing.T) {
+	store, actions := newCounterStore(t)
+	calls := 0
+	onPatch := func(change redux.Change, patch jsonpatch.Patch) {
+		calls++
+	}
+	store.SubscribeToPatches("counter", &onPatch)
+	store.SubscribeToPatches("counter", &onPatch)
+	store.Dispatch(actions.Increment.With(1))
+	if calls != 1 {
+		t.Fatalf("the subscriber was called %v times, want 1", calls)
+	}
+
+	store.UnsubscribeFromPatches("counter", &onPatch)
+	store.Dispatch(actions.Increment.With(1))
+	if calls != 1 {
+		t.Fatalf("the subscriber was called %v times after unsubscribing, want 1", calls)
+	}
+}
+
+type accountActions struct {
+	Deposit redux.Action
+}
+
+type inventoryActions struct {
+	Restock redux.Action
+}
+
+func TestApplyPatchRejectsStatesWithUnexportedFields(t *testing.T) {
+	store, _ := newCounterStore(t)
+	actions := &accountActions{}
+	builder := resolver.GetBusinessParamBuilder()
+	builder.SetInitialState(&account{Owner: "jan", balance: 10})
+	builder.SetActions(actions)
+	builder.On(actions.Deposit, func(state *account, payload int) *account {
+		return &account{Owner: state.Owner, balance: state.balance + payload}
+	})
+	builder.SetSelector("account")
+	store.AddReducer(builder.GetBusinessParam())
+
+	err := catch(func() {
+		store.ApplyPatch("account", jsonpatch.Patch{{Op: jsonpatch.Replace, Path: "/Owner", Value: "ana"}})
+	})
+	if !errors.Is(err, redux.PatchError) {
+		t.Fatalf("the error is %v, want a PatchError", err)
+	}
+	if state := store.GetStateOf("account").(*account); state.Owner != "jan" || state.balance != 10 {
+		t.Fatalf("the state is %+v, want it unchanged", state)
+	}
+}
+
+func TestApplyPatchAcceptsTypesThatMarshalThemselves(t *testing.T) {
+	store, _ := newCounterStore(t)
+	actions := &inventoryActions{}
+	builder := resolver.GetBusinessParamBuilder()
+	builder.SetInitialState(&inventory{Items: map[string]*item{}})
+	builder.SetActions(actions)
+	builder.On(actions.Restock, func(state *inventory) *inventory {
+		return state
+	})
+	builder.SetSelector("inventory")
+	store.AddReducer(builder.GetBusinessParam())
+
+	updated := time.Date(2021, time.March, 4, 10, 30, 0, 0, time.UTC)
+	store.ApplyPatch("inventory", jsonpatch.Patch{{Op: jsonpatch.Replace, Path: "/Updated", Value: updated}})
+
+	if state := store.GetStateOf("inventory").(*inventory); !state.Updated.Equal(updated) {
+		t.Fatalf("the updated time is %v, want %v", state.Updated, updated)
+	}
+}
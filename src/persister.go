@@ -0,0 +1,205 @@
+package redux
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/janmbaco/go-infrastructure/errors/errorschecker"
+)
+
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+type jsonCodec struct{}
+
+func NewJSONCodec() Codec {
+	return &jsonCodec{}
+}
+
+func (c *jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (c *jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+type Persister interface {
+	Save(selector string, state interface{}) error
+	Load(selector string, state interface{}) (bool, error)
+}
+
+type filePersister struct {
+	dir   string
+	codec Codec
+}
+
+func NewFilePersister(dir string, codec Codec) Persister {
+	errorschecker.CheckNilParameter(map[string]interface{}{"codec": codec})
+	if dir == "" {
+		panic("The directory can not be string empty!")
+	}
+	return &filePersister{dir: dir, codec: codec}
+}
+
+func (p *filePersister) Save(selector string, state interface{}) error {
+	data, err := p.codec.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(p.dir, 0o755); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(p.dir, url.PathEscape(selector)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(file.Name(), p.getPath(selector)); err != nil {
+		return err
+	}
+	return syncDir(p.dir)
+}
+
+func (p *filePersister) Load(selector string, state interface{}) (bool, error) {
+	data, err := os.ReadFile(p.getPath(selector))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, p.codec.Unmarshal(data, state)
+}
+
+func (p *filePersister) getPath(selector string) string {
+	return filepath.Join(p.dir, url.PathEscape(selector)+".state")
+}
+
+type persistedState struct {
+	Seq   uint64
+	State interface{}
+}
+
+type persistence struct {
+	persister Persister
+	interval  time.Duration
+	cancel    context.CancelFunc
+	mutex     sync.Mutex
+	dirty     map[string]bool
+}
+
+func (s *store) SetPersister(persister Persister, interval time.Duration) {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.persistence != nil {
+		s.persistence.cancel()
+		s.persistence = nil
+	}
+	if persister == nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(s.ctx)
+	s.persistence = &persistence{persister: persister, interval: interval, cancel: cancel, dirty: make(map[string]bool)}
+	if interval > 0 {
+		go s.persistPeriodically(ctx, s.persistence)
+	}
+}
+
+func (s *store) loadPersistedState(param BusinessParam) interface{} {
+	if s.persistence == nil || !param.IsPersistent() || param.GetInitialState() == nil {
+		return param.GetInitialState()
+	}
+	state := reflect.New(reflect.TypeOf(param.GetInitialState()))
+	persisted := &persistedState{State: state.Interface()}
+	loaded, err := s.persistence.persister.Load(param.GetSelector(), persisted)
+	if err != nil {
+		panic(newStoreErrorFrom(PersistError, err))
+	}
+	if !loaded {
+		return param.GetInitialState()
+	}
+	s.snapshotSeqs[param.GetSelector()] = persisted.Seq
+	return state.Elem().Interface()
+}
+
+func (s *store) persist(selectors []string) {
+	s.mutex.RLock()
+	persistence := s.persistence
+	persistent := make([]string, 0, len(selectors))
+	for _, selector := range selectors {
+		if param, exists := s.params[selector]; exists && param.IsPersistent() {
+			persistent = append(persistent, selector)
+		}
+	}
+	s.mutex.RUnlock()
+	if persistence == nil || len(persistent) == 0 {
+		return
+	}
+
+	persistence.mutex.Lock()
+	for _, selector := range persistent {
+		persistence.dirty[selector] = true
+	}
+	persistence.mutex.Unlock()
+	if persistence.interval <= 0 {
+		s.catchError(func() {
+			s.saveDirtyStates(persistence)
+		})
+	}
+}
+
+func (s *store) persistPeriodically(ctx context.Context, persistence *persistence) {
+	ticker := time.NewTicker(persistence.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.catchError(func() {
+				s.saveDirtyStates(persistence)
+			})
+		}
+	}
+}
+
+func (s *store) saveDirtyStates(persistence *persistence) {
+	persistence.mutex.Lock()
+	defer persistence.mutex.Unlock()
+	for selector := range persistence.dirty {
+		s.mutex.RLock()
+		stateManagement, exists := s.stateManagements[selector]
+		var persisted persistedState
+		if exists {
+			persisted = persistedState{Seq: s.seq, State: stateManagement.GetState()}
+		}
+		s.mutex.RUnlock()
+		if exists {
+			if err := persistence.persister.Save(selector, persisted); err != nil {
+				panic(newStoreErrorFrom(PersistError, err))
+			}
+		}
+		delete(persistence.dirty, selector)
+	}
+}
@@ -0,0 +1,86 @@
+package redux_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/janmbaco/go-redux/src"
+)
+
+type failingPersister struct{}
+
+func (p *failingPersister) Save(selector string, state interface{}) error {
+	return errors.New("failure")
+}
+
+func (p *failingPersister) Load(selector string, state interface{}) (bool, error) {
+	return false, nil
+}
+
+func persistent(builder redux.BusinesParamBuilder) {
+	builder.SetPersistent(true)
+}
+
+func TestSynchronousPersistenceErrorsAreSentToTheErrorHandler(t *testing.T) {
+	store, actions := newCounterStore(t, persistent)
+	var handled error
+	store.SetErrorHandler(func(err error) {
+		handled = err
+	})
+	store.SetPersister(&failingPersister{}, 0)
+	defer store.SetPersister(nil, 0)
+	notified := false
+	onChange := func(interface{}) {
+		notified = true
+	}
+	store.SubscribeTo("counter", &onChange)
+
+	if err := store.TryDispatch(actions.Increment.With(1)); err != nil {
+		t.Fatalf("the dispatch failed with %v after the action was applied", err)
+	}
+	if !errors.Is(handled, redux.PersistError) {
+		t.Fatalf("the handled error is %v, want a PersistError", handled)
+	}
+	if !notified {
+		t.Fatal("the subscriber was not notified")
+	}
+	if state := store.GetStateOf("counter"); state != 1 {
+		t.Fatalf("the state is %v, want 1", state)
+	}
+}
+
+func TestPeriodicPersistenceErrorsAreSentToTheErrorHandler(t *testing.T) {
+	store, actions := newCounterStore(t, persistent)
+	errs := make(chan error, 1)
+	store.SetErrorHandler(func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	})
+	store.SetPersister(&failingPersister{}, time.Millisecond)
+	defer store.SetPersister(nil, 0)
+	store.Dispatch(actions.Increment.With(1))
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, redux.PersistError) {
+			t.Fatalf("the error is %v, want a PersistError", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the error of the persistence was not handled")
+	}
+}
+
+func TestFilePersisterSavesAndLoads(t *testing.T) {
+	persister := redux.NewFilePersister(t.TempDir(), redux.NewJSONCodec())
+	if err := persister.Save("counter", 3); err != nil {
+		t.Fatal(err)
+	}
+	var state int
+	loaded, err := persister.Load("counter", &state)
+	if err != nil || !loaded || state != 3 {
+		t.Fatalf("the loaded state is %v (%v, %v), want 3", state, loaded, err)
+	}
+}
@@ -0,0 +1,161 @@
+package redux
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/janmbaco/go-infrastructure/errors/errorschecker"
+)
+
+type Selector interface {
+	Select(store Store) interface{}
+	GetSelectors() []string
+}
+
+type sliceSelector struct {
+	selector string
+}
+
+type memoizedSelector struct {
+	mutex     sync.Mutex
+	inputs    []Selector
+	selectors []string
+	combiner  func(values ...interface{}) interface{}
+	versions  []uint64
+	result    interface{}
+	computed  bool
+}
+
+type derivedSubscription struct {
+	mutex     sync.Mutex
+	selector  Selector
+	selectors map[string]bool
+	value     interface{}
+}
+
+func Select(selector string) Selector {
+	checkSelector(selector)
+	return &sliceSelector{selector: selector}
+}
+
+func (s *sliceSelector) Select(store Store) interface{} {
+	return store.GetStateOf(s.selector)
+}
+
+func (s *sliceSelector) GetSelectors() []string {
+	return []string{s.selector}
+}
+
+func CreateSelector(combiner func(values ...interface{}) interface{}, inputs ...Selector) Selector {
+	errorschecker.CheckNilParameter(map[string]interface{}{"combiner": combiner})
+	if len(inputs) == 0 {
+		panic("The selector needs at least one input!")
+	}
+	selectors := make([]string, 0, len(inputs))
+	contained := make(map[string]bool)
+	for _, input := range inputs {
+		errorschecker.CheckNilParameter(map[string]interface{}{"input": input})
+		for _, selector := range input.GetSelectors() {
+			if !contained[selector] {
+				contained[selector] = true
+				selectors = append(selectors, selector)
+			}
+		}
+	}
+	return &memoizedSelector{inputs: inputs, selectors: selectors, combiner: combiner}
+}
+
+func (m *memoizedSelector) Select(store Store) interface{} {
+	versions := make([]uint64, 0, len(m.selectors))
+	for _, selector := range m.selectors {
+		versions = append(versions, store.GetVersionOf(selector))
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.computed && reflect.DeepEqual(versions, m.versions) {
+		return m.result
+	}
+	values := make([]interface{}, 0, len(m.inputs))
+	for _, input := range m.inputs {
+		values = append(values, input.Select(store))
+	}
+	m.result = m.combiner(values...)
+	m.versions = versions
+	m.computed = true
+	return m.result
+}
+
+func (m *memoizedSelector) GetSelectors() []string {
+	return m.selectors
+}
+
+func (s *store) GetVersionOf(selector string) uint64 {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	checkSelector(selector)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	s.checkStateManager(selector)
+	return s.versions[selector]
+}
+
+func (s *store) SubscribeToSelector(selector Selector, fn *func(interface{})) {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	errorschecker.CheckNilParameter(map[string]interface{}{"selector": selector, "fn": fn})
+	s.checkActiveSlices(selector.GetSelectors())
+	subscription := &derivedSubscription{selector: selector, selectors: make(map[string]bool), value: selector.Select(s)}
+	for _, dependency := range selector.GetSelectors() {
+		subscription.selectors[dependency] = true
+	}
+	s.derivedMutex.Lock()
+	defer s.derivedMutex.Unlock()
+	s.derivedSubscriptions[fn] = subscription
+}
+
+func (s *store) checkActiveSlices(selectors []string) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	for _, selector := range selectors {
+		s.checkActiveSlice(selector)
+	}
+}
+
+func (s *store) UnsubscribeFromSelector(fn *func(interface{})) {
+	s.derivedMutex.Lock()
+	defer s.derivedMutex.Unlock()
+	delete(s.derivedSubscriptions, fn)
+}
+
+func (s *store) publishDerived(changedSelectors []string) {
+	s.derivedMutex.Lock()
+	subscriptions := make(map[*func(interface{})]*derivedSubscription)
+	for fn, subscription := range s.derivedSubscriptions {
+		if subscription.dependsOn(changedSelectors) {
+			subscriptions[fn] = subscription
+		}
+	}
+	s.derivedMutex.Unlock()
+
+	for fn, subscription := range subscriptions {
+		subscription.publish(s, fn)
+	}
+}
+
+func (d *derivedSubscription) dependsOn(selectors []string) bool {
+	for _, selector := range selectors {
+		if d.selectors[selector] {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *derivedSubscription) publish(store Store, fn *func(interface{})) {
+	d.mutex.Lock()
+	value := d.selector.Select(store)
+	changed := !reflect.DeepEqual(value, d.value)
+	d.value = value
+	d.mutex.Unlock()
+	if changed {
+		(*fn)(value)
+	}
+}
@@ -0,0 +1,107 @@
+package redux_test
+
+import (
+	"testing"
+
+	"github.com/janmbaco/go-redux/src"
+	"github.com/janmbaco/go-redux/src/ioc/resolver"
+	"github.com/janmbaco/go-redux/src/jsonpatch"
+)
+
+type labelActions struct {
+	Set redux.Action
+}
+
+func addLabel(store redux.Store) *labelActions {
+	actions := &labelActions{}
+	builder := resolver.GetBusinessParamBuilder()
+	builder.SetInitialState("")
+	builder.SetActions(actions)
+	builder.On(actions.Set, func(state string, payload string) string {
+		return payload
+	})
+	builder.SetSelector("label")
+	store.AddReducer(builder.GetBusinessParam())
+	return actions
+}
+
+func TestSelectorIsMemoizedOnTheVersionsOfItsSlices(t *testing.T) {
+	store, actions := newCounterStore(t)
+	labels := addLabel(store)
+	calls := 0
+	double := redux.CreateSelector(func(values ...interface{}) interface{} {
+		calls++
+		return values[0].(int) * 2
+	}, redux.Select("counter"))
+
+	double.Select(store)
+	double.Select(store)
+	store.Dispatch(labels.Set.With("label"))
+	double.Select(store)
+	if calls != 1 {
+		t.Fatalf("the combiner was called %v times, want 1", calls)
+	}
+
+	store.Dispatch(actions.Increment.With(2))
+	if value := double.Select(store); value != 4 || calls != 2 {
+		t.Fatalf("the value is %v after %v calls, want 4 after 2 calls", value, calls)
+	}
+}
+
+func TestVersionIsOnlyBumpedWhenTheSliceChanges(t *testing.T) {
+	store, actions := newCounterStore(t)
+	calls := 0
+	double := redux.CreateSelector(func(values ...interface{}) interface{} {
+		calls++
+		return values[0].(int) * 2
+	}, redux.Select("counter"))
+	double.Select(store)
+
+	store.Dispatch(actions.Increment.With(0))
+	store.Dispatch(actions.Reset)
+	store.ApplyPatch("counter", jsonpatch.Patch{{Op: jsonpatch.Replace, Path: "", Value: 0}})
+	store.Replay(redux.NewMemoryActionLog(), 0)
+	if version := store.GetVersionOf("counter"); version != 0 {
+		t.Fatalf("the version is %v, want 0", version)
+	}
+	double.Select(store)
+	if calls != 1 {
+		t.Fatalf("the combiner was called %v times, want 1", calls)
+	}
+
+	store.Dispatch(actions.Increment.With(1))
+	if version := store.GetVersionOf("counter"); version != 1 {
+		t.Fatalf("the version is %v, want 1", version)
+	}
+}
+
+func TestDerivedSubscriptionsAreOnlyEvaluatedWhenTheirSlicesChange(t *testing.T) {
+	store, actions := newCounterStore(t)
+	labels := addLabel(store)
+	evaluations := 0
+	counter := &countingSelector{Selector: redux.Select("counter"), evaluations: &evaluations}
+	values := make([]interface{}, 0)
+	onChange := func(value interface{}) {
+		values = append(values, value)
+	}
+	store.SubscribeToSelector(counter, &onChange)
+
+	store.Dispatch(labels.Set.With("label"))
+	if evaluations != 1 {
+		t.Fatalf("the selector was evaluated %v times, want 1", evaluations)
+	}
+	store.Dispatch(actions.Increment.With(1))
+	if evaluations != 2 || len(values) != 1 || values[0] != 1 {
+		t.Fatalf("the selector was evaluated %v times and notified %v, want 2 times and [1]", evaluations, values)
+	}
+}
+
+type countingSelector struct {
+	redux.Selector
+	evaluations *int
+}
+
+func (s *countingSelector) Select(store redux.Store) interface{} {
+	*s.evaluations++
+	return s.Selector.Select(store)
+}
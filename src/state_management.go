@@ -2,58 +2,100 @@ package redux
 
 import (
 	"reflect"
+	"sync"
 
 	"github.com/janmbaco/go-infrastructure/errors/errorschecker"
 	"github.com/janmbaco/go-infrastructure/eventsmanager"
 	"github.com/janmbaco/go-redux/src/events"
-	"github.com/jinzhu/copier"
 )
 type StateManagement interface{
 	Subscribe(subscription *func(state interface{}))
 	UnSubscribe(subscription *func(state interface{}))
 	GetState() interface{}
+	GetStoredState() interface{}
 	SetState(newState interface{})
+	Update(newState interface{}) bool
+	Publish(state interface{})
+	SubscribeChanges(subscription *func(Change))
+	UnSubscribeChanges(subscription *func(Change))
+	PublishChange(change Change)
 }
 
 type stateManagement struct {
 	*events.SelectorSubscribeEventHandler
+	changes           *events.ChangeEventHandler
+	mutex             sync.RWMutex
 	storePublisher    eventsmanager.Publisher
 	selectorPublisher eventsmanager.Publisher
 	state             reflect.Value
 	typ               reflect.Type
 	subscriptors      []func()
 	selector          string
+	equality          EqualityFunc
+	cloner            Cloner
 }
 
-func NewStateManager(initialState interface{}, selector string, storePublisher eventsmanager.Publisher, subscriptions eventsmanager.Subscriptions, selectorPublisher eventsmanager.Publisher) StateManagement {
-	errorschecker.CheckNilParameter(map[string]interface{}{"initialState": initialState, "selector": selector, "storePublisher": storePublisher, "subscriptions": subscriptions, "selectorPublisher": selectorPublisher})
+func NewStateManager(initialState interface{}, selector string, storePublisher eventsmanager.Publisher, subscriptions eventsmanager.Subscriptions, selectorPublisher eventsmanager.Publisher, equality EqualityFunc, cloner Cloner) StateManagement {
+	errorschecker.CheckNilParameter(map[string]interface{}{"initialState": initialState, "selector": selector, "storePublisher": storePublisher, "subscriptions": subscriptions, "selectorPublisher": selectorPublisher, "equality": equality, "cloner": cloner})
 	if selector == "" {
 		panic("The selector can not be string empty!")
 	}
 	return &stateManagement{
 		SelectorSubscribeEventHandler: events.NewSelectorSubscribeEventHandler(subscriptions),
+		changes:                       events.NewChangeEventHandler(subscriptions, changeFuncType),
 		storePublisher:                storePublisher,
 		state:                         reflect.ValueOf(initialState),
 		typ:                           reflect.TypeOf(initialState),
 		selectorPublisher:             selectorPublisher,
 		selector:                      selector,
+		equality:                      equality,
+		cloner:                        cloner,
 	}
 
 }
 
 func (s *stateManagement) GetState() interface{} {
-	newState := s.state
-	if s.typ.Kind() == reflect.Ptr {
-		newState = reflect.New(s.typ.Elem())
-		errorschecker.TryPanic(copier.Copy(newState.Interface(), s.state.Interface()))
-	}
-	return newState.Interface()
+	s.mutex.RLock()
+	state := s.state
+	s.mutex.RUnlock()
+	return s.cloner(state.Interface())
+}
+
+func (s *stateManagement) GetStoredState() interface{} {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.state.Interface()
 }
 
 func (s *stateManagement) SetState(newState interface{}) {
-	if !reflect.DeepEqual(newState, s.state.Interface()) {
-		s.state = reflect.ValueOf(newState)
+	if s.Update(newState) {
 		s.storePublisher.Publish(&events.StoreSubscribeEvent{})
-		s.selectorPublisher.Publish(&events.SelectorSubscribeEvent{State: s.GetState()})
+		s.Publish(s.GetStoredState())
 	}
 }
+
+func (s *stateManagement) Update(newState interface{}) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.equality(s.state.Interface(), newState) {
+		return false
+	}
+	s.state = reflect.ValueOf(newState)
+	return true
+}
+
+func (s *stateManagement) Publish(state interface{}) {
+	s.selectorPublisher.Publish(&events.SelectorSubscribeEvent{State: s.cloner(state)})
+}
+
+func (s *stateManagement) SubscribeChanges(subscription *func(Change)) {
+	s.changes.Subscribe(subscription)
+}
+
+func (s *stateManagement) UnSubscribeChanges(subscription *func(Change)) {
+	s.changes.UnSubscribe(subscription)
+}
+
+func (s *stateManagement) PublishChange(change Change) {
+	s.selectorPublisher.Publish(s.changes.NewEvent(change))
+}
@@ -9,6 +9,8 @@ type StateManagementFactoryParamter struct{
 	InitialState interface{}
 	Selector string
 	StorePublisher eventsmanager.Publisher
+	Equality EqualityFunc
+	Cloner Cloner
 }
 
 type StateManagementFactory interface {
@@ -20,10 +22,10 @@ type stateManagementFactory struct {
 }
 
 func NewStateManagementFactory(container dependencyinjection.Container) StateManagementFactory {
-	container.Register().AsType(new(StateManagement), NewStateManager, map[uint]string{0: _initialState, 1: _selector, 2: _storePublisher})
+	container.Register().AsType(new(StateManagement), NewStateManager, map[uint]string{0: _initialState, 1: _selector, 2: _storePublisher, 5: _equality, 6: _cloner})
 	return &stateManagementFactory{container.Resolver()}
 }
 
 func (s *stateManagementFactory) Create(parameter StateManagementFactoryParamter) StateManagement {
-	return s.resolver.Type(new(StateManagement), map[string]interface{}{_initialState: parameter.InitialState, _selector: parameter.Selector, _storePublisher: parameter.StorePublisher}).(*stateManagement)
+	return s.resolver.Type(new(StateManagement), map[string]interface{}{_initialState: parameter.InitialState, _selector: parameter.Selector, _storePublisher: parameter.StorePublisher, _equality: parameter.Equality, _cloner: parameter.Cloner}).(*stateManagement)
 }
@@ -1,100 +1,515 @@
 package redux
 
 import (
+	"context"
 	"fmt"
-	"reflect"
+	"sync"
+	"time"
 
 	"github.com/janmbaco/go-infrastructure/errors"
 	"github.com/janmbaco/go-infrastructure/errors/errorschecker"
 	"github.com/janmbaco/go-infrastructure/eventsmanager"
+	"github.com/janmbaco/go-infrastructure/logs"
 	"github.com/janmbaco/go-redux/src/events"
+	"github.com/janmbaco/go-redux/src/jsonpatch"
 )
 
 type Store interface {
 	GetState() interface{}
 	Dispatch(Action)
+	DispatchContext(context.Context, Action)
+	TryDispatch(Action) error
+	TryDispatchContext(context.Context, Action) error
+	DispatchBatch(...Action)
+	Transaction(func(tx Tx) error)
 	Subscribe(*func())
 	Unsubscribe(*func())
 	AddReducer(BusinessParam)
+	TryAddReducer(BusinessParam) error
 	RemoveReducer(string)
 	GetStateOf(string) interface{}
+	GetActionsObjects() map[string]ActionsObject
 	SubscribeTo(string, *func(interface{}))
 	UnsubscribeFrom(string, *func(interface{}))
+	ApplyMiddleware(...Middleware)
+	DispatchThunk(context.Context, Thunk) <-chan error
+	AddEffect(Action, EffectPolicy, *Effect)
+	RemoveEffect(Action, *Effect)
+	Shutdown()
+	SetActionLog(ActionLog)
+	GetActionLog() ActionLog
+	Replay(ActionLog, uint64)
+	SetPersister(Persister, time.Duration)
+	Recover(ActionLog)
+	DecodeAction(ActionLogEntry) (Action, error)
+	GetVersionOf(string) uint64
+	SubscribeToSelector(Selector, *func(interface{}))
+	UnsubscribeFromSelector(*func(interface{}))
+	AddComputed(string, func(...interface{}) interface{}, ...string)
+	SubscribeChanges(*func(Change))
+	UnsubscribeChanges(*func(Change))
+	SubscribeResults(*func(DispatchResult))
+	UnsubscribeResults(*func(DispatchResult))
+	SubscribeToChanges(string, *func(Change))
+	UnsubscribeFromChanges(string, *func(Change))
+	ApplyPatch(string, jsonpatch.Patch)
+	SubscribeToPatches(string, *func(Change, jsonpatch.Patch))
+	UnsubscribeFromPatches(string, *func(Change, jsonpatch.Patch))
+	Watch(context.Context, string, WatchOptions) <-chan Change
+	SetDevMode(bool)
+	SetErrorHandler(func(error))
 }
 
 type store struct {
 	*events.StoreSubscribeEventHandler
-	errorDefer    errors.ErrorDefer
-	publisher     eventsmanager.Publisher
-	reducers      map[string]Reducer
-	actionsObject map[string]ActionsObject
-	stateManagements map[string]StateManagement
+	changes                *events.ChangeEventHandler
+	results                *events.ResultEventHandler
+	mutex                  sync.RWMutex
+	ctx                    context.Context
+	cancel                 context.CancelFunc
+	errorDefer             errors.ErrorDefer
+	logger                 logs.Logger
+	publisher              eventsmanager.Publisher
+	params                 map[string]BusinessParam
+	reducers               map[string]Reducer
+	actionsObject          map[string]ActionsObject
+	selectorsByAction      map[Action][]string
+	versions               map[string]uint64
+	histories              map[string]*history
+	stateManagements       map[string]StateManagement
 	stateManagementFactory StateManagementFactory
+	middlewares            []Middleware
+	dispatcher             DispatchFunc
+	effects                *effects
+	actionLog              ActionLog
+	seq                    uint64
+	persistence            *persistence
+	snapshotSeqs           map[string]uint64
+	devMode                bool
+	errorHandler           func(error)
+	computed               map[string]*computedSlice
+	computedOrder          []string
+	patchMutex             sync.Mutex
+	patchSubscriptions     map[patchSubscription]*func(Change)
+	watchMutex             sync.Mutex
+	watchers               map[string][]*watcher
+	derivedMutex           sync.Mutex
+	derivedSubscriptions   map[*func(interface{})]*derivedSubscription
+	outboxMutex            sync.Mutex
+	outbox                 []notification
+	draining               bool
 }
 
-func NewStore(errorDefer errors.ErrorDefer, subscriptions eventsmanager.Subscriptions, publisher eventsmanager.Publisher, stateManagementFactory StateManagementFactory) Store {
-	errorschecker.CheckNilParameter(map[string]interface{}{"errorDefer": errorDefer, "subscriptions": subscriptions, "publisher": publisher, "stateManagementResolver":stateManagementFactory})
-	return &store{
+type notification struct {
+	results []DispatchResult
+	effects bool
+	batch   *notificationBatch
+}
+
+type notificationBatch struct {
+	open    bool
+	pending int
+	results []DispatchResult
+}
+
+func NewStore(errorDefer errors.ErrorDefer, logger logs.Logger, subscriptions eventsmanager.Subscriptions, publisher eventsmanager.Publisher, stateManagementFactory StateManagementFactory) Store {
+	errorschecker.CheckNilParameter(map[string]interface{}{"errorDefer": errorDefer, "logger": logger, "subscriptions": subscriptions, "publisher": publisher, "stateManagementResolver": stateManagementFactory})
+	result := &store{
 		StoreSubscribeEventHandler: events.NewStoreSubscribeEventHandler(subscriptions),
+		changes:                    events.NewChangeEventHandler(subscriptions, changeFuncType),
+		results:                    events.NewResultEventHandler(subscriptions, resultFuncType),
 		errorDefer:                 errorDefer,
+		logger:                     logger,
+		params:                     make(map[string]BusinessParam),
 		reducers:                   make(map[string]Reducer),
 		actionsObject:              make(map[string]ActionsObject),
+		selectorsByAction:          make(map[Action][]string),
+		versions:                   make(map[string]uint64),
+		histories:                  make(map[string]*history),
+		snapshotSeqs:               make(map[string]uint64),
+		derivedSubscriptions:       make(map[*func(interface{})]*derivedSubscription),
+		computed:                   make(map[string]*computedSlice),
+		patchSubscriptions:         make(map[patchSubscription]*func(Change)),
+		watchers:                   make(map[string][]*watcher),
+		computedOrder:              make([]string, 0),
 		stateManagements:           make(map[string]StateManagement),
 		publisher:                  publisher,
-		stateManagementFactory:    stateManagementFactory,
+		stateManagementFactory:     stateManagementFactory,
+		middlewares:                make([]Middleware, 0),
+		outbox:                     make([]notification, 0),
 	}
+	result.ctx, result.cancel = context.WithCancel(context.Background())
+	result.dispatcher = result.reduce
+	result.effects = newEffects(result.ctx, result.catchError)
+	return result
 }
 
 func (s *store) AddReducer(param BusinessParam) {
 	defer s.errorDefer.TryThrowError(s.errorPipe)
+	s.addReducer(param)
+}
+
+func (s *store) TryAddReducer(param BusinessParam) error {
+	return s.try(func() {
+		s.addReducer(param)
+	})
+}
+
+func (s *store) addReducer(param BusinessParam) {
 	errorschecker.CheckNilParameter(map[string]interface{}{"param": param})
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
 	if _, ko := s.reducers[param.GetSelector()]; ko {
 		panic(newStoreError(MultipleReducerForSelectorError, "Cannot add multiple Reducer with the same selector!"))
 	}
+	if _, ko := s.computed[param.GetSelector()]; ko {
+		panic(newStoreError(MultipleReducerForSelectorError, "Cannot add multiple Reducer with the same selector!"))
+	}
 	for _, actionsObject := range s.actionsObject {
 		if actionsObject == param.GetActionsObject() {
 			panic(newStoreError(MultipleReducerForActionsObjectError, "Cannot add multiple reducer with the same ActionsObject!"))
 		}
 	}
+	s.params[param.GetSelector()] = param
 	s.reducers[param.GetSelector()] = param.GetReducer()
 	s.actionsObject[param.GetSelector()] = param.GetActionsObject()
+	actions := append(param.GetActionsObject().GetActions(), param.GetExtraActions()...)
+	if param.GetHistory() != nil {
+		history := newHistory(param.GetHistory())
+		s.histories[param.GetSelector()] = history
+		actions = append(actions, history.getActions()...)
+	}
+	for _, action := range actions {
+		s.selectorsByAction[action.GetOrigin()] = append(s.selectorsByAction[action.GetOrigin()], param.GetSelector())
+	}
 	if _, contains := s.stateManagements[param.GetSelector()]; !contains {
-		s.stateManagements[param.GetSelector()] = s.stateManagementFactory.Create(StateManagementFactoryParamter{param.GetInitialState(), param.GetSelector(), s.publisher})
+		s.stateManagements[param.GetSelector()] = s.stateManagementFactory.Create(StateManagementFactoryParamter{s.loadPersistedState(param), param.GetSelector(), s.publisher, getEqualityFunc(param), getCloner(param)})
 	}
 }
 
 func (s *store) RemoveReducer(selector string) {
 	defer s.errorDefer.TryThrowError(s.errorPipe)
 	checkSelector(selector)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	s.checkStateManager(selector)
+	delete(s.params, selector)
 	if _, ok := s.reducers[selector]; ok {
 		delete(s.reducers, selector)
 	}
 	if _, ok := s.actionsObject[selector]; ok {
 		delete(s.actionsObject, selector)
 	}
+	delete(s.histories, selector)
+	s.closeWatchers(selector)
+	if _, ok := s.computed[selector]; ok {
+		delete(s.computed, selector)
+		s.computedOrder = sortComputed(s.computed)
+	}
+	for action, selectors := range s.selectorsByAction {
+		for i, sel := range selectors {
+			if sel == selector {
+				selectors = append(selectors[:i:i], selectors[i+1:]...)
+				break
+			}
+		}
+		if len(selectors) == 0 {
+			delete(s.selectorsByAction, action)
+		} else {
+			s.selectorsByAction[action] = selectors
+		}
+	}
 }
 
 func (s *store) Dispatch(action Action) {
 	defer s.errorDefer.TryThrowError(s.errorPipe)
+	s.dispatch(action)
+}
+
+func (s *store) TryDispatch(action Action) error {
+	return s.try(func() {
+		s.dispatch(action)
+	})
+}
+
+func (s *store) dispatch(action Action) {
 	errorschecker.CheckNilParameter(map[string]interface{}{"action": action})
-	var selector string
-	for sel, actionObject := range s.actionsObject {
-		if actionObject.Contains(action) {
-			selector = sel
-			break
+	s.mutex.RLock()
+	dispatcher := s.dispatcher
+	s.mutex.RUnlock()
+	defer s.flush()
+	dispatcher(action)
+}
+
+func (s *store) DispatchBatch(actions ...Action) {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	for _, action := range actions {
+		errorschecker.CheckNilParameter(map[string]interface{}{"action": action})
+	}
+	batch := &notificationBatch{open: true, results: make([]DispatchResult, 0, len(actions))}
+	s.mutex.RLock()
+	dispatcher := applyMiddlewares(s.middlewares, func(action Action) DispatchResult {
+		return s.reduceInBatch(batch, action)
+	})
+	s.mutex.RUnlock()
+	defer s.flush()
+	defer s.closeBatch(batch)
+	for _, action := range actions {
+		dispatcher(action)
+	}
+}
+
+func (s *store) ApplyMiddleware(middlewares ...Middleware) {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	for _, middleware := range middlewares {
+		if middleware == nil {
+			panic(newStoreError(NilMiddlewareError, "The middleware can not be nil!"))
 		}
 	}
-	if selector == "" {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.middlewares = append(s.middlewares, middlewares...)
+	s.dispatcher = applyMiddlewares(s.middlewares, s.reduce)
+}
+
+func (s *store) DispatchContext(ctx context.Context, action Action) {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	s.dispatchContext(ctx, action)
+}
+
+func (s *store) TryDispatchContext(ctx context.Context, action Action) error {
+	return s.try(func() {
+		s.dispatchContext(ctx, action)
+	})
+}
+
+func (s *store) dispatchContext(ctx context.Context, action Action) {
+	errorschecker.CheckNilParameter(map[string]interface{}{"ctx": ctx, "action": action})
+	checkContext(ctx)
+	s.dispatch(action.WithContext(ctx))
+}
+
+func (s *store) reduce(action Action) DispatchResult {
+	return s.reduceInBatch(nil, action)
+}
+
+func (s *store) reduceInBatch(batch *notificationBatch, action Action) DispatchResult {
+	errorschecker.CheckNilParameter(map[string]interface{}{"action": action})
+	checkContext(action.GetContext())
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	result := s.applyAction(action, s.selectorsByAction[action.GetOrigin()], true)
+	s.enqueue(batch, true, result)
+	return result
+}
+
+func (s *store) applyAction(action Action, selectors []string, logged bool) DispatchResult {
+	if len(selectors) == 0 {
 		panic(newStoreError(AnyReducerForThisActionError, "There are not any Reducers that execute this action!"))
 	}
 
-	s.stateManagements[selector].SetState((*s.reducers[selector])(s.stateManagements[selector].GetState(), action))
+	result := DispatchResult{Action: action, Slices: make([]SliceResult, 0, len(selectors))}
+	nextStates := make([]interface{}, 0, len(selectors))
+	for _, selector := range selectors {
+		stateManagement := s.stateManagements[selector]
+		result.Slices = append(result.Slices, SliceResult{Selector: selector, PrevState: stateManagement.GetState()})
+		if s.isHistoryAction(selector, action) {
+			nextStates = append(nextStates, nil)
+			continue
+		}
+		nextStates = append(nextStates, callReducer(s.devMode, selector, s.reducers[selector], stateManagement.GetStoredState(), s.reducerClonerOf(selector), action))
+	}
+
+	if logged {
+		s.appendToLog(action)
+	}
+	s.seq++
+	result.Seq = s.seq
+	for i, selector := range selectors {
+		stateManagement := s.stateManagements[selector]
+		if s.isHistoryAction(selector, action) {
+			result.Slices[i].Changed = stateManagement.Update(s.histories[selector].travel(action, result.Slices[i].PrevState))
+		} else if result.Slices[i].Changed = stateManagement.Update(nextStates[i]); result.Slices[i].Changed && s.histories[selector] != nil {
+			s.histories[selector].record(result.Slices[i].PrevState, action)
+		}
+		if result.Slices[i].Changed {
+			s.versions[selector]++
+		}
+		result.Slices[i].NextState = stateManagement.GetState()
+	}
+	result.Slices = append(result.Slices, s.recompute(changedSelectors(result.Slices))...)
+	return result
+}
+
+func (s *store) SetDevMode(enabled bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.devMode = enabled
+}
+
+func callReducer(devMode bool, selector string, reducer Reducer, stored interface{}, cloner Cloner, action Action) interface{} {
+	if !devMode {
+		return (*reducer)(cloner(stored), action)
+	}
+	state := cloner(stored)
+	fingerprint := structuralHash(state)
+	nextState := (*reducer)(state, action)
+	if structuralHash(state) != fingerprint {
+		panic(newStoreError(MutationError, fmt.Sprintf("The reducer of the selector '%v' has mutated the state with the action '%v'!", selector, action.GetType())))
+	}
+	return nextState
+}
+
+func (s *store) enqueue(batch *notificationBatch, effects bool, results ...DispatchResult) {
+	s.outboxMutex.Lock()
+	defer s.outboxMutex.Unlock()
+	if batch != nil {
+		batch.pending++
+	}
+	s.outbox = append(s.outbox, notification{results: results, effects: effects, batch: batch})
+}
+
+func (s *store) closeBatch(batch *notificationBatch) {
+	s.outboxMutex.Lock()
+	defer s.outboxMutex.Unlock()
+	batch.open = false
+}
+
+func (s *store) flush() {
+	s.outboxMutex.Lock()
+	if s.draining {
+		s.outboxMutex.Unlock()
+		return
+	}
+	s.draining = true
+	s.outboxMutex.Unlock()
+
+	for {
+		notification, exists := s.nextNotification()
+		if !exists {
+			return
+		}
+		s.catchError(func() {
+			s.notify(notification.effects, notification.results...)
+		})
+	}
+}
+
+func (s *store) nextNotification() (notification, bool) {
+	s.outboxMutex.Lock()
+	defer s.outboxMutex.Unlock()
+	for len(s.outbox) > 0 {
+		head := s.outbox[0]
+		if head.batch != nil && head.batch.open {
+			break
+		}
+		s.outbox[0] = notification{}
+		s.outbox = s.outbox[1:]
+		if head.batch == nil {
+			return head, true
+		}
+		head.batch.results = append(head.batch.results, head.results...)
+		head.batch.pending--
+		if head.batch.pending == 0 {
+			return notification{results: head.batch.results, effects: head.effects}, true
+		}
+	}
+	s.draining = false
+	return notification{}, false
+}
+
+func (s *store) notify(effects bool, results ...DispatchResult) {
+	s.publish(results...)
+	if !effects {
+		return
+	}
+	for _, result := range results {
+		if len(result.Slices) > 0 {
+			s.effects.run(result)
+		}
+	}
+}
+
+func (s *store) publish(results ...DispatchResult) {
+	for _, result := range results {
+		s.publisher.Publish(s.results.NewEvent(result))
+	}
+	selectors := make([]string, 0)
+	changes := make(map[string]*Change)
+	updated := make(map[string]bool)
+	for _, result := range results {
+		for _, slice := range result.Slices {
+			change, exists := changes[slice.Selector]
+			if !exists {
+				selectors = append(selectors, slice.Selector)
+				change = &Change{Selector: slice.Selector, Prev: slice.PrevState}
+				changes[slice.Selector] = change
+			}
+			change.Action = result.Action
+			change.Next = slice.NextState
+			change.Seq = result.Seq
+			updated[slice.Selector] = updated[slice.Selector] || slice.Changed
+		}
+	}
+
+	changed := make([]StateManagement, 0)
+	changedSelectors := make([]string, 0)
+	s.mutex.RLock()
+	for _, selector := range selectors {
+		if stateManagement, exists := s.stateManagements[selector]; exists && updated[selector] {
+			changed = append(changed, stateManagement)
+			changedSelectors = append(changedSelectors, selector)
+		}
+	}
+	s.mutex.RUnlock()
+
+	if len(changed) > 0 {
+		s.publisher.Publish(&events.StoreSubscribeEvent{})
+		for _, selector := range changedSelectors {
+			s.publisher.Publish(s.changes.NewEvent(*changes[selector]))
+		}
+		watched := make([]Change, 0, len(changedSelectors))
+		for i, stateManagement := range changed {
+			stateManagement.Publish(changes[changedSelectors[i]].Next)
+			stateManagement.PublishChange(*changes[changedSelectors[i]])
+			watched = append(watched, *changes[changedSelectors[i]])
+		}
+		s.publishWatchers(watched)
+		s.publishDerived(changedSelectors)
+		s.persist(changedSelectors)
+	}
+}
+
+func (s *store) AddEffect(action Action, policy EffectPolicy, effect *Effect) {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	errorschecker.CheckNilParameter(map[string]interface{}{"action": action, "effect": effect})
+	s.effects.add(action, policy, effect)
+}
+
+func (s *store) RemoveEffect(action Action, effect *Effect) {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	errorschecker.CheckNilParameter(map[string]interface{}{"action": action, "effect": effect})
+	s.effects.remove(action, effect)
+}
+
+func (s *store) Shutdown() {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	s.cancel()
+	s.closeAllWatchers()
+	s.effects.shutdown()
+	s.mutex.RLock()
+	persistence := s.persistence
+	s.mutex.RUnlock()
+	if persistence != nil {
+		s.saveDirtyStates(persistence)
+	}
 }
 
 func (s *store) GetState() interface{} {
 	defer s.errorDefer.TryThrowError(s.errorPipe)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
 	globalState := make(map[string]interface{})
 	for selector, stateManager := range s.stateManagements {
 		globalState[selector] = stateManager.GetState()
@@ -105,24 +520,86 @@ func (s *store) GetState() interface{} {
 func (s *store) GetStateOf(selector string) interface{} {
 	defer s.errorDefer.TryThrowError(s.errorPipe)
 	checkSelector(selector)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
 	s.checkStateManager(selector)
 	return s.stateManagements[selector].GetState()
 }
 
+func (s *store) GetActionsObjects() map[string]ActionsObject {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	result := make(map[string]ActionsObject, len(s.actionsObject))
+	for selector, actionsObject := range s.actionsObject {
+		result[selector] = actionsObject
+	}
+	return result
+}
+
 func (s *store) SubscribeTo(selector string, fn *func(interface{})) {
 	defer s.errorDefer.TryThrowError(s.errorPipe)
 	checkSelector(selector)
-	s.checkStateManager(selector)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	s.checkActiveSlice(selector)
 	s.stateManagements[selector].Subscribe(fn)
 }
 
 func (s *store) UnsubscribeFrom(selector string, fn *func(interface{})) {
 	defer s.errorDefer.TryThrowError(s.errorPipe)
 	checkSelector(selector)
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
 	s.checkStateManager(selector)
 	s.stateManagements[selector].UnSubscribe(fn)
 }
 
+func (s *store) isHistoryAction(selector string, action Action) bool {
+	history, exists := s.histories[selector]
+	return exists && history.isHistoryAction(action)
+}
+
+func (s *store) equalityOf(selector string) EqualityFunc {
+	if param, exists := s.params[selector]; exists {
+		return getEqualityFunc(param)
+	}
+	return DeepEquality
+}
+
+func getEqualityFunc(param BusinessParam) EqualityFunc {
+	if param.GetEqualityFunc() == nil {
+		return DeepEquality
+	}
+	return param.GetEqualityFunc()
+}
+
+func (s *store) clonerOf(selector string) Cloner {
+	if param, exists := s.params[selector]; exists {
+		return getCloner(param)
+	}
+	return DeepCloner
+}
+
+func (s *store) reducerClonerOf(selector string) Cloner {
+	if param, exists := s.params[selector]; exists && param.IsReadOnlyState() {
+		return ReadOnlyCloner
+	}
+	return s.clonerOf(selector)
+}
+
+func getCloner(param BusinessParam) Cloner {
+	if param.GetCloner() == nil {
+		return DeepCloner
+	}
+	return param.GetCloner()
+}
+
+func checkContext(ctx context.Context) {
+	if err := ctx.Err(); err != nil {
+		panic(newStoreErrorFrom(ContextDoneError, err))
+	}
+}
+
 func checkSelector(selector string) {
 	if selector == "" {
 		panic(newStoreError(EmptySelectorError, "The selector can not be string empty!"))
@@ -134,18 +611,51 @@ func (s *store) checkStateManager(selector string) {
 	}
 }
 
+func (s *store) checkActiveSlice(selector string) {
+	s.checkStateManager(selector)
+	_, hasReducer := s.params[selector]
+	_, isComputed := s.computed[selector]
+	if !hasReducer && !isComputed {
+		panic(newStoreError(RemovedSliceError, fmt.Sprintf("The Reducer of the selector '%v' has been removed!", selector)))
+	}
+}
+
+func (s *store) try(fn func()) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = s.errorPipe(toError(recovered))
+		}
+	}()
+	fn()
+	return nil
+}
+
+func (s *store) SetErrorHandler(handler func(error)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.errorHandler = handler
+}
+
+func (s *store) catchError(fn func()) {
+	err := s.try(fn)
+	if err == nil {
+		return
+	}
+	s.mutex.RLock()
+	handler := s.errorHandler
+	s.mutex.RUnlock()
+	if handler == nil {
+		s.logger.Error(err.Error())
+		return
+	}
+	handler(err)
+}
+
 func (s *store) errorPipe(err error) error {
 	resultError := err
 
-	if errType := reflect.Indirect(reflect.ValueOf(err)).Type(); !errType.Implements(reflect.TypeOf((*StoreError)(nil)).Elem()) {
-		errorType := UnexpectedStoreError
-		resultError = &storeError{
-			CustomizableError: errors.CustomizableError{
-				Message:       err.Error(),
-				InternalError: err,
-			},
-			ErrorType: errorType,
-		}
+	if _, isStoreError := err.(StoreError); !isStoreError {
+		resultError = newStoreErrorFrom(UnexpectedStoreError, err)
 	}
 	return resultError
 }
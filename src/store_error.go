@@ -1,6 +1,8 @@
 package redux
 
 import (
+	"fmt"
+
 	"github.com/janmbaco/go-infrastructure/errors"
 )
 
@@ -13,8 +15,58 @@ const (
 	AnyStateBySelectorError
 	MultipleReducerForSelectorError
 	MultipleReducerForActionsObjectError
+	NilMiddlewareError
+	ThunkError
+	EffectError
+	EffectAlreadyAddedError
+	TransactionError
+	TransactionConflictError
+	TransactionClosedError
+	HistoryActionInTransactionError
+	ActionLogError
+	PersistError
+	ComputedCycleError
+	PatchError
+	ContextDoneError
+	PayloadTypeError
+	BusinessParamError
+	MutationError
+	RemovedSliceError
 )
 
+var storeErrorTypeNames = [...]string{
+	"UnexpectedStoreError",
+	"AnyReducerForThisActionError",
+	"EmptySelectorError",
+	"AnyStateBySelectorError",
+	"MultipleReducerForSelectorError",
+	"MultipleReducerForActionsObjectError",
+	"NilMiddlewareError",
+	"ThunkError",
+	"EffectError",
+	"EffectAlreadyAddedError",
+	"TransactionError",
+	"TransactionConflictError",
+	"TransactionClosedError",
+	"HistoryActionInTransactionError",
+	"ActionLogError",
+	"PersistError",
+	"ComputedCycleError",
+	"PatchError",
+	"ContextDoneError",
+	"PayloadTypeError",
+	"BusinessParamError",
+	"MutationError",
+	"RemovedSliceError",
+}
+
+func (t StoreErrorType) Error() string {
+	if int(t) < len(storeErrorTypeNames) {
+		return storeErrorTypeNames[t]
+	}
+	return fmt.Sprintf("StoreErrorType(%d)", uint8(t))
+}
+
 type StoreError interface {
 	errors.CustomError
 	GetErrorType() StoreErrorType
@@ -28,13 +80,47 @@ type storeError struct {
 func newStoreError(errorType StoreErrorType, message string) StoreError {
 	return &storeError{
 		CustomizableError: errors.CustomizableError{
-			Message: message,
+			Message:       message,
 			InternalError: nil,
 		},
-		ErrorType: errorType, 
+		ErrorType: errorType,
+	}
+}
+
+func newStoreErrorFrom(errorType StoreErrorType, err error) StoreError {
+	return &storeError{
+		CustomizableError: errors.CustomizableError{
+			Message:       err.Error(),
+			InternalError: err,
+		},
+		ErrorType: errorType,
 	}
 }
 
 func (e *storeError) GetErrorType() StoreErrorType {
 	return e.ErrorType
 }
+
+func (e *storeError) Is(target error) bool {
+	errorType, isErrorType := target.(StoreErrorType)
+	return isErrorType && errorType == e.ErrorType
+}
+
+func (e *storeError) As(target interface{}) bool {
+	errorType, isErrorType := target.(*StoreErrorType)
+	if isErrorType {
+		*errorType = e.ErrorType
+	}
+	return isErrorType
+}
+
+func (e *storeError) Unwrap() error {
+	return e.InternalError
+}
+
+func toError(recovered interface{}) error {
+	if err, isError := recovered.(error); isError {
+		return err
+	}
+	return fmt.Errorf("%v", recovered)
+}
@@ -0,0 +1,72 @@
+package redux_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/janmbaco/go-redux/src"
+)
+
+func TestWithEReturnsAPayloadTypeError(t *testing.T) {
+	_, actions := newCounterStore(t)
+
+	action, err := actions.Increment.WithE("two")
+	if action != nil || !errors.Is(err, redux.PayloadTypeError) {
+		t.Fatalf("WithE returned %v and %v, want a PayloadTypeError", action, err)
+	}
+	if action, err = actions.Increment.WithE(2); err != nil || action.GetPayload().Interface() != 2 {
+		t.Fatalf("WithE returned %v and %v, want the payload 2", action, err)
+	}
+	if err := catch(func() { actions.Increment.With("two") }); !errors.Is(err, redux.PayloadTypeError) {
+		t.Fatalf("With panicked with %v, want a PayloadTypeError", err)
+	}
+}
+
+func TestTryMethodsReturnTheErrorsOfTheStore(t *testing.T) {
+	store, actions := newCounterStore(t)
+	_, foreign := newCounterBuilder()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, test := range []struct {
+		name string
+		try  func() error
+		err  redux.StoreErrorType
+	}{
+		{name: "TryDispatch", try: func() error { return store.TryDispatch(foreign.Reset) }, err: redux.AnyReducerForThisActionError},
+		{name: "TryDispatchContext", try: func() error { return store.TryDispatchContext(ctx, actions.Reset) }, err: redux.ContextDoneError},
+		{name: "TryAddReducer", try: func() error {
+			builder, actions := newCounterBuilder()
+			builder.On(actions.Increment, func(state int, payload int) int { return state })
+			builder.On(actions.Reset, func(state int) int { return state })
+			builder.On(actions.Fail, func(state int) int { return state })
+			builder.SetSelector("counter")
+			return store.TryAddReducer(builder.GetBusinessParam())
+		}, err: redux.MultipleReducerForSelectorError},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.try()
+			if err == nil {
+				t.Fatal("the error is nil")
+			}
+			if !errors.Is(err, test.err) {
+				t.Fatalf("the error is %v, want %v", err, test.err)
+			}
+			var errorType redux.StoreErrorType
+			if !errors.As(err, &errorType) || errorType != test.err {
+				t.Fatalf("errors.As extracted %v from %v, want %v", errorType, err, test.err)
+			}
+			var storeError redux.StoreError
+			if !errors.As(err, &storeError) || storeError.GetErrorType() != test.err {
+				t.Fatalf("errors.As did not extract the StoreError of %v", err)
+			}
+		})
+	}
+	if err := store.TryDispatch(actions.Increment.With(1)); err != nil {
+		t.Fatalf("TryDispatch failed with %v", err)
+	}
+	if state := store.GetStateOf("counter"); state != 1 {
+		t.Fatalf("the state is %v, want 1", state)
+	}
+}
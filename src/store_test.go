@@ -0,0 +1,350 @@
+package redux_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/janmbaco/go-infrastructure/dependencyinjection/static"
+	"github.com/janmbaco/go-redux/src"
+	"github.com/janmbaco/go-redux/src/ioc/resolver"
+)
+
+type counterActions struct {
+	Increment redux.Action
+	Reset     redux.Action
+	Fail      redux.Action
+}
+
+func init() {
+	static.Container.Register().AsType(new(redux.Store), redux.NewStore, nil)
+}
+
+func newCounterStore(t *testing.T, options ...func(redux.BusinesParamBuilder)) (redux.Store, *counterActions) {
+	t.Helper()
+	actions := &counterActions{}
+	builder := resolver.GetBusinessParamBuilder()
+	for _, option := range options {
+		option(builder)
+	}
+	builder.SetInitialState(0)
+	builder.SetActions(actions)
+	builder.On(actions.Increment, func(state int, payload int) int {
+		return state + payload
+	})
+	builder.On(actions.Reset, func(state int) int {
+		return 0
+	})
+	builder.On(actions.Fail, func(state int) int {
+		panic("failure")
+	})
+	builder.SetSelector("counter")
+	store := resolver.GetStore()
+	store.AddReducer(builder.GetBusinessParam())
+	t.Cleanup(store.Shutdown)
+	return store, actions
+}
+
+func TestConcurrentDispatchNotifiesInSeqOrder(t *testing.T) {
+	store, actions := newCounterStore(t)
+	const goroutines, dispatches = 8, 200
+
+	var mutex sync.Mutex
+	seqs := make([]uint64, 0, goroutines*dispatches)
+	onChange := func(change redux.Change) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		seqs = append(seqs, change.Seq)
+	}
+	store.SubscribeChanges(&onChange)
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < dispatches; j++ {
+				store.Dispatch(actions.Increment.With(1))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if state := store.GetStateOf("counter"); state != goroutines*dispatches {
+		t.Fatalf("the state is %v, want %v", state, goroutines*dispatches)
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	if len(seqs) != goroutines*dispatches {
+		t.Fatalf("%v changes were notified, want %v", len(seqs), goroutines*dispatches)
+	}
+	for i := 1; i < len(seqs); i++ {
+		if seqs[i] <= seqs[i-1] {
+			t.Fatalf("the change with seq %v was notified after the change with seq %v", seqs[i], seqs[i-1])
+		}
+	}
+}
+
+func TestConcurrentBatchesNotifyInSeqOrder(t *testing.T) {
+	store, actions := newCounterStore(t)
+	const goroutines, iterations = 6, 100
+
+	var mutex sync.Mutex
+	seqs := make([]uint64, 0)
+	onChange := func(change redux.Change) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		seqs = append(seqs, change.Seq)
+	}
+	store.SubscribeToChanges("counter", &onChange)
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < iterations; j++ {
+				switch i % 3 {
+				case 0:
+					store.Dispatch(actions.Increment.With(1))
+				case 1:
+					store.DispatchBatch(actions.Increment.With(1), actions.Increment.With(1))
+				default:
+					store.TryDispatch(actions.Increment.With(0))
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	for i := 1; i < len(seqs); i++ {
+		if seqs[i] <= seqs[i-1] {
+			t.Fatalf("the change with seq %v was notified after the change with seq %v", seqs[i], seqs[i-1])
+		}
+	}
+	if want := goroutines / 3 * iterations * 3; store.GetStateOf("counter") != want {
+		t.Fatalf("the state is %v, want %v", store.GetStateOf("counter"), want)
+	}
+}
+
+func TestConcurrentSubscribeWhileDispatching(t *testing.T) {
+	store, actions := newCounterStore(t)
+	const goroutines, iterations = 4, 100
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < iterations; j++ {
+				store.Dispatch(actions.Increment.With(1))
+				store.GetState()
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < iterations; j++ {
+				onChange := func(interface{}) {}
+				onStoreChange := func() {}
+				store.SubscribeTo("counter", &onChange)
+				store.Subscribe(&onStoreChange)
+				store.UnsubscribeFrom("counter", &onChange)
+				store.Unsubscribe(&onStoreChange)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if state := store.GetStateOf("counter"); state != goroutines*iterations {
+		t.Fatalf("the state is %v, want %v", state, goroutines*iterations)
+	}
+}
+
+func TestDispatchFromSubscriberIsNotifiedAfterTheCurrentChange(t *testing.T) {
+	store, actions := newCounterStore(t)
+
+	states := make([]interface{}, 0)
+	onChange := func(state interface{}) {
+		states = append(states, state)
+		if state == 1 {
+			store.Dispatch(actions.Increment.With(1))
+		}
+	}
+	store.SubscribeTo("counter", &onChange)
+	store.Dispatch(actions.Increment.With(1))
+
+	if len(states) != 2 || states[0] != 1 || states[1] != 2 {
+		t.Fatalf("the notified states are %v, want [1 2]", states)
+	}
+}
+
+func TestSubscribeResultsReceivesEachActionOfABatch(t *testing.T) {
+	store, actions := newCounterStore(t)
+	states := make([]interface{}, 0)
+	onResult := func(result redux.DispatchResult) {
+		states = append(states, result.Slices[0].NextState)
+	}
+	store.SubscribeResults(&onResult)
+	defer store.UnsubscribeResults(&onResult)
+
+	store.DispatchBatch(actions.Increment.With(1), actions.Increment.With(2))
+	store.Dispatch(actions.Increment.With(3))
+	if len(states) != 3 || states[0] != 1 || states[1] != 3 || states[2] != 6 {
+		t.Fatalf("the results carry the states %v, want [1 3 6]", states)
+	}
+}
+
+func TestSubscribersReceiveTheStateOfTheirChange(t *testing.T) {
+	store, actions := newCounterStore(t)
+	dispatched := false
+	onStoreChange := func() {
+		if !dispatched {
+			dispatched = true
+			store.Dispatch(actions.Increment.With(1))
+		}
+	}
+	store.Subscribe(&onStoreChange)
+	states := make([]interface{}, 0)
+	onChange := func(state interface{}) {
+		states = append(states, state)
+	}
+	store.SubscribeTo("counter", &onChange)
+
+	store.Dispatch(actions.Increment.With(1))
+
+	if len(states) != 2 || states[0] != 1 || states[1] != 2 {
+		t.Fatalf("the notified states are %v, want [1 2]", states)
+	}
+}
+
+func TestSubscriberPanicsAreSentToTheErrorHandler(t *testing.T) {
+	store, actions := newCounterStore(t)
+	var handled error
+	store.SetErrorHandler(func(err error) {
+		handled = err
+	})
+	onChange := func(interface{}) {
+		panic("failure")
+	}
+	store.SubscribeToSelector(redux.Select("counter"), &onChange)
+
+	if err := store.TryDispatch(actions.Increment.With(1)); err != nil {
+		t.Fatalf("the dispatch failed with the panic of a subscriber: %v", err)
+	}
+	if handled == nil {
+		t.Fatal("the panic of the subscriber was not handled")
+	}
+	if state := store.GetStateOf("counter"); state != 1 {
+		t.Fatalf("the state is %v, want 1", state)
+	}
+}
+
+type auditActions struct {
+	Clear redux.Action
+}
+
+func addAudit(store redux.Store, counter *counterActions) {
+	actions := &auditActions{}
+	builder := resolver.GetBusinessParamBuilder()
+	builder.SetInitialState(0)
+	builder.SetActions(actions)
+	builder.On(actions.Clear, func(state int) int {
+		return 0
+	})
+	builder.OnExtra(counter.Increment, func(state int, payload int) int {
+		return state + payload*10
+	})
+	builder.SetSelector("audit")
+	store.AddReducer(builder.GetBusinessParam())
+}
+
+func TestActionIsReducedByEverySliceThatHandlesIt(t *testing.T) {
+	store, actions := newCounterStore(t)
+	addAudit(store, actions)
+	notifications := 0
+	onStoreChange := func() {
+		notifications++
+	}
+	store.Subscribe(&onStoreChange)
+	seqs := make(map[string]uint64)
+	onChange := func(change redux.Change) {
+		seqs[change.Selector] = change.Seq
+	}
+	store.SubscribeChanges(&onChange)
+
+	store.Dispatch(actions.Increment.With(2))
+
+	if counter, audit := store.GetStateOf("counter"), store.GetStateOf("audit"); counter != 2 || audit != 20 {
+		t.Fatalf("the states are %v and %v, want 2 and 20", counter, audit)
+	}
+	if notifications != 1 {
+		t.Fatalf("%v notifications, want 1", notifications)
+	}
+	if len(seqs) != 2 || seqs["counter"] != seqs["audit"] {
+		t.Fatalf("the changes have the seqs %v, want the same seq for both slices", seqs)
+	}
+}
+
+func TestBatchNotifiesOnceOnlyTheSlicesThatChanged(t *testing.T) {
+	store, actions := newCounterStore(t)
+	labels := addLabel(store)
+	notifications := 0
+	onStoreChange := func() {
+		notifications++
+	}
+	store.Subscribe(&onStoreChange)
+	changes := make([]redux.Change, 0)
+	onChange := func(change redux.Change) {
+		changes = append(changes, change)
+	}
+	store.SubscribeChanges(&onChange)
+	labelNotified := false
+	onLabel := func(interface{}) {
+		labelNotified = true
+	}
+	store.SubscribeTo("label", &onLabel)
+
+	store.DispatchBatch(actions.Increment.With(1), labels.Set.With(""), actions.Increment.With(2))
+
+	if notifications != 1 {
+		t.Fatalf("%v notifications, want 1", notifications)
+	}
+	if len(changes) != 1 || changes[0].Selector != "counter" {
+		t.Fatalf("the changes are %v, want only the counter", changes)
+	}
+	if changes[0].Prev != 0 || changes[0].Next != 3 {
+		t.Fatalf("the change goes from %v to %v, want from 0 to 3", changes[0].Prev, changes[0].Next)
+	}
+	if labelNotified {
+		t.Fatal("the unchanged label was notified")
+	}
+}
+
+func TestChangeCarriesPrevNextSeqAndAction(t *testing.T) {
+	store, actions := newCounterStore(t)
+	labels := addLabel(store)
+	changes := make([]redux.Change, 0)
+	onChange := func(change redux.Change) {
+		changes = append(changes, change)
+	}
+	store.SubscribeToChanges("counter", &onChange)
+
+	store.Dispatch(actions.Increment.With(2))
+	store.Dispatch(labels.Set.With("label"))
+	store.Dispatch(actions.Increment.With(3).WithMeta("requestId", "42"))
+
+	if len(changes) != 2 {
+		t.Fatalf("%v changes, want 2", len(changes))
+	}
+	change := changes[1]
+	if change.Selector != "counter" || change.Prev != 2 || change.Next != 5 || change.Seq != 3 {
+		t.Fatalf("the change is %+v, want counter from 2 to 5 with seq 3", change)
+	}
+	if change.Action.GetOrigin() != actions.Increment || change.Action.GetPayload().Interface() != 3 || change.Action.GetMeta("requestId") != "42" {
+		t.Fatalf("the change carries the action %v, want the dispatched Increment", change.Action.GetType())
+	}
+	if changes[0].Seq != 1 || changes[0].Prev != 0 || changes[0].Next != 2 {
+		t.Fatalf("the first change is %+v, want from 0 to 2 with seq 1", changes[0])
+	}
+}
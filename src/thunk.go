@@ -0,0 +1,29 @@
+package redux
+
+import (
+	"context"
+
+	"github.com/janmbaco/go-infrastructure/errors/errorschecker"
+)
+
+type Thunk func(ctx context.Context, dispatch func(Action), getState func(selector string) interface{}) error
+
+func (s *store) DispatchThunk(ctx context.Context, thunk Thunk) <-chan error {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	errorschecker.CheckNilParameter(map[string]interface{}{"ctx": ctx, "thunk": thunk})
+	done := make(chan error, 1)
+	go func() {
+		defer close(done)
+		ctx, cancel := mergeContext(ctx, s.ctx)
+		defer cancel()
+		dispatch := func(action Action) {
+			s.DispatchContext(ctx, action)
+		}
+		done <- s.try(func() {
+			if err := thunk(ctx, dispatch, s.GetStateOf); err != nil {
+				panic(newStoreErrorFrom(ThunkError, err))
+			}
+		})
+	}()
+	return done
+}
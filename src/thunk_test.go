@@ -0,0 +1,82 @@
+package redux_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/janmbaco/go-redux/src"
+)
+
+func TestDispatchThunkRunsAsynchronously(t *testing.T) {
+	store, actions := newCounterStore(t)
+	release := make(chan struct{})
+	done := store.DispatchThunk(context.Background(), func(ctx context.Context, dispatch func(redux.Action), getState func(string) interface{}) error {
+		<-release
+		dispatch(actions.Increment.With(2))
+		return nil
+	})
+
+	if state := store.GetStateOf("counter"); state != 0 {
+		t.Fatalf("the state is %v before the thunk ends, want 0", state)
+	}
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("the thunk has failed: %v", err)
+	}
+	if state := store.GetStateOf("counter"); state != 2 {
+		t.Fatalf("the state is %v, want 2", state)
+	}
+	if _, open := <-done; open {
+		t.Fatal("the channel of the thunk is not closed")
+	}
+}
+
+func TestDispatchThunkReturnsErrors(t *testing.T) {
+	store, _ := newCounterStore(t)
+	failure := errors.New("failure")
+	err := <-store.DispatchThunk(context.Background(), func(ctx context.Context, dispatch func(redux.Action), getState func(string) interface{}) error {
+		return failure
+	})
+	if !errors.Is(err, redux.ThunkError) || !errors.Is(err, failure) {
+		t.Fatalf("the error is %v, want a ThunkError wrapping %v", err, failure)
+	}
+
+	err = <-store.DispatchThunk(context.Background(), func(ctx context.Context, dispatch func(redux.Action), getState func(string) interface{}) error {
+		getState("unknown")
+		return nil
+	})
+	if !errors.Is(err, redux.AnyStateBySelectorError) {
+		t.Fatalf("the error is %v, want an AnyStateBySelectorError", err)
+	}
+}
+
+func TestThunkDispatchesWithItsContext(t *testing.T) {
+	store, actions := newCounterStore(t)
+	var received context.Context
+	store.ApplyMiddleware(func(next redux.DispatchFunc) redux.DispatchFunc {
+		return func(action redux.Action) redux.DispatchResult {
+			received = action.GetContext()
+			return next(action)
+		}
+	})
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), requestKey{}, "42"))
+	defer cancel()
+
+	err := <-store.DispatchThunk(ctx, func(ctx context.Context, dispatch func(redux.Action), getState func(string) interface{}) error {
+		dispatch(actions.Increment.With(1))
+		cancel()
+		dispatch(actions.Increment.With(1))
+		return nil
+	})
+
+	if received == nil || received.Value(requestKey{}) != "42" {
+		t.Fatal("the context of the thunk has not reached the middleware")
+	}
+	if !errors.Is(err, redux.ContextDoneError) {
+		t.Fatalf("the error is %v, want a ContextDoneError", err)
+	}
+	if state := store.GetStateOf("counter"); state != 1 {
+		t.Fatalf("the state is %v, want 1", state)
+	}
+}
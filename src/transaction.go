@@ -0,0 +1,171 @@
+package redux
+
+import (
+	"github.com/janmbaco/go-infrastructure/errors/errorschecker"
+)
+
+type Tx interface {
+	Dispatch(Action)
+	GetStateOf(string) interface{}
+}
+
+type transaction struct {
+	store       *store
+	dispatcher  DispatchFunc
+	states      map[string]interface{}
+	prevStates  map[string]interface{}
+	lastActions map[string]Action
+	versions    map[string]uint64
+	results     []DispatchResult
+	closed      bool
+	failed      bool
+}
+
+func (s *store) Transaction(fn func(tx Tx) error) {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	errorschecker.CheckNilParameter(map[string]interface{}{"fn": fn})
+	tx := &transaction{
+		store:       s,
+		states:      make(map[string]interface{}),
+		prevStates:  make(map[string]interface{}),
+		lastActions: make(map[string]Action),
+		versions:    make(map[string]uint64),
+		results:     make([]DispatchResult, 0),
+	}
+	s.mutex.RLock()
+	tx.dispatcher = applyMiddlewares(s.middlewares, tx.reduce)
+	s.mutex.RUnlock()
+
+	defer func() {
+		tx.closed = true
+	}()
+	if err := fn(tx); err != nil {
+		panic(newStoreErrorFrom(TransactionError, err))
+	}
+	if tx.failed {
+		panic(newStoreError(TransactionError, "The transaction can not be committed because a dispatch has failed!"))
+	}
+	tx.commit()
+}
+
+func (tx *transaction) Dispatch(action Action) {
+	errorschecker.CheckNilParameter(map[string]interface{}{"action": action})
+	tx.checkClosed()
+	dispatched := false
+	defer func() {
+		tx.failed = tx.failed || !dispatched
+	}()
+	tx.results = append(tx.results, tx.dispatcher(action))
+	dispatched = true
+}
+
+func (tx *transaction) GetStateOf(selector string) interface{} {
+	checkSelector(selector)
+	tx.checkClosed()
+	tx.store.mutex.RLock()
+	defer tx.store.mutex.RUnlock()
+	if state, exists := tx.states[selector]; exists {
+		return tx.store.clonerOf(selector)(state)
+	}
+	tx.store.checkStateManager(selector)
+	return tx.store.stateManagements[selector].GetState()
+}
+
+func (tx *transaction) reduce(action Action) DispatchResult {
+	errorschecker.CheckNilParameter(map[string]interface{}{"action": action})
+	tx.checkClosed()
+	tx.store.mutex.RLock()
+	selectors := tx.store.selectorsByAction[action.GetOrigin()]
+	reducers := make([]Reducer, 0, len(selectors))
+	cloners := make([]Cloner, 0, len(selectors))
+	for _, selector := range selectors {
+		if tx.store.isHistoryAction(selector, action) {
+			tx.store.mutex.RUnlock()
+			panic(newStoreError(HistoryActionInTransactionError, "The actions of the history can not be dispatched in a transaction!"))
+		}
+		reducers = append(reducers, tx.store.reducers[selector])
+		cloners = append(cloners, tx.store.reducerClonerOf(selector))
+		if _, exists := tx.states[selector]; !exists {
+			tx.states[selector] = tx.store.reducerClonerOf(selector)(tx.store.stateManagements[selector].GetStoredState())
+			tx.prevStates[selector] = tx.store.stateManagements[selector].GetState()
+			tx.versions[selector] = tx.store.versions[selector]
+		}
+	}
+	devMode := tx.store.devMode
+	tx.store.mutex.RUnlock()
+	if len(selectors) == 0 {
+		panic(newStoreError(AnyReducerForThisActionError, "There are not any Reducers that execute this action!"))
+	}
+
+	result := DispatchResult{Action: action, Slices: make([]SliceResult, 0, len(selectors))}
+	nextStates := make([]interface{}, 0, len(selectors))
+	for i, selector := range selectors {
+		result.Slices = append(result.Slices, SliceResult{Selector: selector, PrevState: tx.states[selector]})
+		nextStates = append(nextStates, callReducer(devMode, selector, reducers[i], tx.states[selector], cloners[i], action))
+	}
+	for i, selector := range selectors {
+		tx.states[selector] = nextStates[i]
+		tx.lastActions[selector] = action
+		result.Slices[i].NextState = tx.store.clonerOf(selector)(nextStates[i])
+	}
+	return result
+}
+
+func (tx *transaction) commit() {
+	defer tx.store.flush()
+	tx.apply()
+}
+
+func (tx *transaction) apply() {
+	tx.store.mutex.Lock()
+	defer tx.store.mutex.Unlock()
+	for selector, version := range tx.versions {
+		if tx.store.versions[selector] != version {
+			panic(newStoreError(TransactionConflictError, "The state has been modified outside the transaction!"))
+		}
+	}
+	actions := make([]Action, 0, len(tx.results))
+	for _, result := range tx.results {
+		if len(result.Slices) > 0 {
+			actions = append(actions, result.Action)
+		}
+	}
+	tx.store.appendToLog(actions...)
+	for i, result := range tx.results {
+		if len(result.Slices) > 0 {
+			tx.store.seq++
+			tx.results[i].Seq = tx.store.seq
+		}
+	}
+	selectors := make([]string, 0, len(tx.states))
+	changed := make(map[string]bool, len(tx.states))
+	for selector, state := range tx.states {
+		changed[selector] = tx.store.stateManagements[selector].Update(state)
+		if changed[selector] && tx.store.histories[selector] != nil {
+			tx.store.histories[selector].record(tx.prevStates[selector], tx.lastActions[selector])
+		}
+		if changed[selector] {
+			tx.store.versions[selector]++
+			selectors = append(selectors, selector)
+		}
+	}
+	for _, result := range tx.results {
+		for i := range result.Slices {
+			result.Slices[i].Changed = changed[result.Slices[i].Selector]
+		}
+	}
+	computed := tx.store.recompute(selectors)
+	for i := len(tx.results) - 1; i >= 0 && len(computed) > 0; i-- {
+		if len(tx.results[i].Slices) > 0 {
+			tx.results[i].Slices = append(tx.results[i].Slices, computed...)
+			break
+		}
+	}
+	tx.store.enqueue(nil, true, tx.results...)
+}
+
+func (tx *transaction) checkClosed() {
+	if tx.closed {
+		panic(newStoreError(TransactionClosedError, "The transaction is already closed!"))
+	}
+}
@@ -0,0 +1,73 @@
+package redux_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/janmbaco/go-redux/src"
+)
+
+type failingActionLog struct {
+	redux.ActionLog
+}
+
+func (l *failingActionLog) Append(entries ...redux.ActionLogEntry) error {
+	if len(entries) > 1 {
+		return errors.New("failure")
+	}
+	return l.ActionLog.Append(entries...)
+}
+
+func TestTransactionIsNotCommittedWhenARecoveredDispatchFailed(t *testing.T) {
+	store, actions := newCounterStore(t)
+	err := tryTransaction(store, func(tx redux.Tx) error {
+		tx.Dispatch(actions.Increment.With(1))
+		func() {
+			defer func() {
+				recover()
+			}()
+			tx.Dispatch(actions.Fail)
+		}()
+		tx.Dispatch(actions.Increment.With(1))
+		return nil
+	})
+
+	if !errors.Is(err, redux.TransactionError) {
+		t.Fatalf("the error is %v, want a TransactionError", err)
+	}
+	if state := store.GetStateOf("counter"); state != 0 {
+		t.Fatalf("the state is %v, want 0", state)
+	}
+}
+
+func TestTransactionIsNotCommittedWhenTheLogFails(t *testing.T) {
+	store, actions := newCounterStore(t)
+	log := redux.NewMemoryActionLog()
+	store.SetActionLog(&failingActionLog{log})
+
+	err := tryTransaction(store, func(tx redux.Tx) error {
+		tx.Dispatch(actions.Increment.With(1))
+		tx.Dispatch(actions.Increment.With(2))
+		return nil
+	})
+
+	if !errors.Is(err, redux.ActionLogError) {
+		t.Fatalf("the error is %v, want an ActionLogError", err)
+	}
+	if state := store.GetStateOf("counter"); state != 0 {
+		t.Fatalf("the state is %v, want 0", state)
+	}
+	if entries, _ := log.Entries(0); len(entries) != 0 {
+		t.Fatalf("the log has %v entries, want 0", len(entries))
+	}
+}
+
+func tryTransaction(store redux.Store, fn func(tx redux.Tx) error) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = recovered.(error)
+		}
+	}()
+	store.Transaction(fn)
+	return nil
+}
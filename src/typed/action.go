@@ -0,0 +1,13 @@
+package typed
+
+import (
+	"github.com/janmbaco/go-redux/src"
+)
+
+type Action[P any] struct {
+	redux.Action
+}
+
+func (a *Action[P]) WithPayload(payload P) redux.Action {
+	return a.Action.With(payload)
+}
@@ -0,0 +1,54 @@
+package typed
+
+import (
+	"github.com/janmbaco/go-infrastructure/errors/errorschecker"
+	"github.com/janmbaco/go-redux/src"
+)
+
+type Slice[S any] struct {
+	builder  redux.BusinesParamBuilder
+	selector string
+}
+
+func NewSlice[S any](builder redux.BusinesParamBuilder, selector string, initialState S, actions interface{}) *Slice[S] {
+	errorschecker.CheckNilParameter(map[string]interface{}{"builder": builder, "actions": actions})
+	builder = builder.NewBuilder()
+	builder.SetInitialState(initialState).SetActions(actions).SetSelector(selector)
+	return &Slice[S]{builder: builder, selector: selector}
+}
+
+func On[S, P any](slice *Slice[S], action *Action[P], reducer func(state S, payload P) S) *Slice[S] {
+	errorschecker.CheckNilParameter(map[string]interface{}{"slice": slice, "action": action, "reducer": reducer})
+	slice.builder.On(action.Action, reducer)
+	return slice
+}
+
+func OnEmpty[S any](slice *Slice[S], action redux.Action, reducer func(state S) S) *Slice[S] {
+	errorschecker.CheckNilParameter(map[string]interface{}{"slice": slice, "action": action, "reducer": reducer})
+	slice.builder.On(action, reducer)
+	return slice
+}
+
+func OnExtra[S, P any](slice *Slice[S], action *Action[P], reducer func(state S, payload P) S) *Slice[S] {
+	errorschecker.CheckNilParameter(map[string]interface{}{"slice": slice, "action": action, "reducer": reducer})
+	slice.builder.OnExtra(action.Action, reducer)
+	return slice
+}
+
+func OnExtraEmpty[S any](slice *Slice[S], action redux.Action, reducer func(state S) S) *Slice[S] {
+	errorschecker.CheckNilParameter(map[string]interface{}{"slice": slice, "action": action, "reducer": reducer})
+	slice.builder.OnExtra(action, reducer)
+	return slice
+}
+
+func (s *Slice[S]) GetSelector() string {
+	return s.selector
+}
+
+func (s *Slice[S]) GetBusinessParam() redux.BusinessParam {
+	return s.builder.GetBusinessParam()
+}
+
+func (s *Slice[S]) Build() (redux.BusinessParam, error) {
+	return s.builder.Build()
+}
@@ -0,0 +1,37 @@
+package typed_test
+
+import (
+	"testing"
+
+	"github.com/janmbaco/go-redux/src"
+	"github.com/janmbaco/go-redux/src/ioc/resolver"
+	"github.com/janmbaco/go-redux/src/typed"
+)
+
+type counterActions struct {
+	Add *typed.Action[int]
+}
+
+type labelActions struct {
+	Set *typed.Action[string]
+}
+
+func TestInterleavedSlicesKeepTheirOwnConfiguration(t *testing.T) {
+	counterActions, labelActions := &counterActions{}, &labelActions{}
+	builder := resolver.GetBusinessParamBuilder()
+	counter := typed.NewSlice(builder, "counter", 0, counterActions)
+	label := typed.NewSlice(builder, "label", "", labelActions)
+	typed.On(counter, counterActions.Add, func(state int, payload int) int { return state + payload })
+	typed.On(label, labelActions.Set, func(state string, payload string) string { return payload })
+
+	params := []redux.BusinessParam{counter.GetBusinessParam(), label.GetBusinessParam()}
+	if params[0].GetSelector() != "counter" || params[1].GetSelector() != "label" {
+		t.Fatalf("the selectors are '%v' and '%v'", params[0].GetSelector(), params[1].GetSelector())
+	}
+	if params[0].GetInitialState() != 0 || params[1].GetInitialState() != "" {
+		t.Fatalf("the initial states are '%v' and '%v'", params[0].GetInitialState(), params[1].GetInitialState())
+	}
+	if !params[0].GetActionsObject().Contains(counterActions.Add) || !params[1].GetActionsObject().Contains(labelActions.Set) {
+		t.Fatal("the slices do not contain their own actions")
+	}
+}
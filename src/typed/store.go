@@ -0,0 +1,56 @@
+package typed
+
+import (
+	"sync"
+
+	"github.com/janmbaco/go-infrastructure/errors/errorschecker"
+	"github.com/janmbaco/go-redux/src"
+)
+
+type Store[S any] struct {
+	store         redux.Store
+	selector      string
+	mutex         sync.Mutex
+	subscriptions map[*func(S)]*func(interface{})
+}
+
+func NewStore[S any](store redux.Store, slice *Slice[S]) *Store[S] {
+	errorschecker.CheckNilParameter(map[string]interface{}{"store": store, "slice": slice})
+	return &Store[S]{store: store, selector: slice.selector, subscriptions: make(map[*func(S)]*func(interface{}))}
+}
+
+func GetStateOf[S any](store redux.Store, slice *Slice[S]) S {
+	errorschecker.CheckNilParameter(map[string]interface{}{"store": store, "slice": slice})
+	return store.GetStateOf(slice.selector).(S)
+}
+
+func (s *Store[S]) GetState() S {
+	return s.store.GetStateOf(s.selector).(S)
+}
+
+func (s *Store[S]) Dispatch(action redux.Action) {
+	s.store.Dispatch(action)
+}
+
+func (s *Store[S]) SubscribeTo(subscription *func(state S)) {
+	errorschecker.CheckNilParameter(map[string]interface{}{"subscription": subscription})
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, exists := s.subscriptions[subscription]; exists {
+		return
+	}
+	fn := func(state interface{}) {
+		(*subscription)(state.(S))
+	}
+	s.subscriptions[subscription] = &fn
+	s.store.SubscribeTo(s.selector, &fn)
+}
+
+func (s *Store[S]) UnsubscribeFrom(subscription *func(state S)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if fn, exists := s.subscriptions[subscription]; exists {
+		delete(s.subscriptions, subscription)
+		s.store.UnsubscribeFrom(s.selector, fn)
+	}
+}
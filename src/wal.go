@@ -0,0 +1,405 @@
+package redux
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/janmbaco/go-infrastructure/errors/errorschecker"
+)
+
+type syncKind uint8
+
+const (
+	syncAlways syncKind = iota
+	syncEvery
+	syncNever
+)
+
+type SyncPolicy struct {
+	kind     syncKind
+	interval time.Duration
+}
+
+var (
+	SyncAlways = SyncPolicy{kind: syncAlways}
+	SyncNever  = SyncPolicy{kind: syncNever}
+)
+
+func SyncEvery(interval time.Duration) SyncPolicy {
+	if interval <= 0 {
+		panic("The interval must be greater than zero!")
+	}
+	return SyncPolicy{kind: syncEvery, interval: interval}
+}
+
+type WALOptions struct {
+	SegmentSize int64
+	Sync        SyncPolicy
+}
+
+type WAL interface {
+	ActionLog
+	Sync() error
+	Compact(seq uint64) error
+	Close() error
+}
+
+const (
+	defaultSegmentSize = 64 << 20
+	walHeaderSize      = 8
+	walExtension       = ".wal"
+)
+
+var (
+	walTable        = crc32.MakeTable(crc32.Castagnoli)
+	errTornRecord   = errors.New("torn WAL record")
+	errWALClosed    = errors.New("the WAL is closed")
+	errWALOutOfSync = errors.New("the WAL sequence must be increasing")
+)
+
+type walSegment struct {
+	firstSeq uint64
+	path     string
+}
+
+type wal struct {
+	mutex    sync.Mutex
+	dir      string
+	options  WALOptions
+	segments []walSegment
+	file     *os.File
+	size     int64
+	lastSeq  uint64
+	dirty    bool
+	closed   bool
+	err      error
+	cancel   context.CancelFunc
+}
+
+func NewWAL(dir string, options WALOptions) WAL {
+	if dir == "" {
+		panic("The directory can not be string empty!")
+	}
+	if options.SegmentSize <= 0 {
+		options.SegmentSize = defaultSegmentSize
+	}
+	errorschecker.TryPanic(os.MkdirAll(dir, 0o755))
+	result := &wal{dir: dir, options: options, segments: make([]walSegment, 0)}
+	errorschecker.TryPanic(result.open())
+	if options.Sync.kind == syncEvery {
+		var ctx context.Context
+		ctx, result.cancel = context.WithCancel(context.Background())
+		go result.syncPeriodically(ctx)
+	}
+	return result
+}
+
+func (w *wal) Append(entries ...ActionLogEntry) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.closed {
+		return errWALClosed
+	}
+	if w.err != nil {
+		return w.err
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	lastSeq := w.lastSeq
+	for _, entry := range entries {
+		if entry.Seq <= lastSeq {
+			return errWALOutOfSync
+		}
+		lastSeq = entry.Seq
+	}
+	var data []byte
+	var err error
+	if len(entries) == 1 {
+		data, err = json.Marshal(entries[0])
+	} else {
+		data, err = json.Marshal(entries)
+	}
+	if err != nil {
+		return err
+	}
+	record := make([]byte, walHeaderSize+len(data))
+	binary.BigEndian.PutUint32(record[0:4], uint32(len(data)))
+	binary.BigEndian.PutUint32(record[4:8], crc32.Checksum(data, walTable))
+	copy(record[walHeaderSize:], data)
+
+	if w.file == nil || w.size >= w.options.SegmentSize {
+		if err := w.rotate(entries[0].Seq); err != nil {
+			return err
+		}
+	}
+	if _, err := w.file.Write(record); err != nil {
+		w.file.Truncate(w.size)
+		return err
+	}
+	w.size += int64(len(record))
+	w.lastSeq = lastSeq
+	if w.options.Sync.kind == syncAlways {
+		return w.file.Sync()
+	}
+	w.dirty = true
+	return nil
+}
+
+func (w *wal) Entries(fromSeq uint64) ([]ActionLogEntry, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	result := make([]ActionLogEntry, 0)
+	for i, segment := range w.segments {
+		if i+1 < len(w.segments) && w.segments[i+1].firstSeq <= fromSeq {
+			continue
+		}
+		_, err := scanSegment(segment.path, func(entry ActionLogEntry) {
+			if entry.Seq >= fromSeq {
+				result = append(result, entry)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (w *wal) LastSeq() (uint64, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.lastSeq, nil
+}
+
+func (w *wal) Sync() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.sync()
+}
+
+func (w *wal) Compact(seq uint64) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	removed := false
+	for len(w.segments) > 1 && w.segments[1].firstSeq <= seq+1 {
+		if err := os.Remove(w.segments[0].path); err != nil {
+			return err
+		}
+		w.segments = w.segments[1:]
+		removed = true
+	}
+	if removed && w.options.Sync.kind != syncNever {
+		return syncDir(w.dir)
+	}
+	return nil
+}
+
+func (w *wal) Close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if w.cancel != nil {
+		w.cancel()
+	}
+	if w.file == nil {
+		return nil
+	}
+	err := w.sync()
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (w *wal) open() error {
+	paths, err := filepath.Glob(filepath.Join(w.dir, "*"+walExtension))
+	if err != nil {
+		return err
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		firstSeq, err := strconv.ParseUint(strings.TrimSuffix(filepath.Base(path), walExtension), 10, 64)
+		if err != nil {
+			continue
+		}
+		w.segments = append(w.segments, walSegment{firstSeq: firstSeq, path: path})
+	}
+	if len(w.segments) == 0 {
+		return nil
+	}
+
+	for i, segment := range w.segments {
+		size, err := scanSegment(segment.path, func(entry ActionLogEntry) {
+			w.lastSeq = entry.Seq
+		})
+		isLast := i == len(w.segments)-1
+		if err == errTornRecord && isLast {
+			if err := w.truncate(segment.path, size); err != nil {
+				return err
+			}
+		} else if err == errTornRecord {
+			return fmt.Errorf("the WAL segment '%v' is corrupted at offset %v", segment.path, size)
+		} else if err != nil {
+			return err
+		}
+		if isLast {
+			w.size = size
+		}
+	}
+	w.file, err = os.OpenFile(w.segments[len(w.segments)-1].path, os.O_WRONLY|os.O_APPEND, 0o644)
+	return err
+}
+
+func (w *wal) truncate(path string, size int64) error {
+	file, err := os.OpenFile(path, os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := file.Truncate(size); err != nil {
+		return err
+	}
+	if w.options.Sync.kind == syncNever {
+		return nil
+	}
+	if err := file.Sync(); err != nil {
+		return err
+	}
+	return syncDir(w.dir)
+}
+
+func (w *wal) rotate(firstSeq uint64) error {
+	if w.file != nil {
+		if err := w.sync(); err != nil {
+			return err
+		}
+		if err := w.file.Close(); err != nil {
+			return err
+		}
+		w.file = nil
+	}
+	path := filepath.Join(w.dir, fmt.Sprintf("%020d%v", firstSeq, walExtension))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	if w.options.Sync.kind != syncNever {
+		if err := syncDir(w.dir); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	w.file = file
+	w.size = 0
+	w.segments = append(w.segments, walSegment{firstSeq: firstSeq, path: path})
+	return nil
+}
+
+func (w *wal) sync() error {
+	if w.file == nil || !w.dirty || w.options.Sync.kind == syncNever {
+		return nil
+	}
+	if err := w.file.Sync(); err != nil {
+		return err
+	}
+	w.dirty = false
+	return nil
+}
+
+func (w *wal) syncPeriodically(ctx context.Context) {
+	ticker := time.NewTicker(w.options.Sync.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.mutex.Lock()
+			if err := w.sync(); err != nil && w.err == nil {
+				w.err = err
+			}
+			w.mutex.Unlock()
+		}
+	}
+}
+
+func scanSegment(path string, fn func(ActionLogEntry)) (int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	reader := bufio.NewReader(file)
+	header := make([]byte, walHeaderSize)
+	var offset int64
+	for {
+		if _, err := io.ReadFull(reader, header); err == io.EOF {
+			return offset, nil
+		} else if err == io.ErrUnexpectedEOF {
+			return offset, errTornRecord
+		} else if err != nil {
+			return offset, err
+		}
+		length := int64(binary.BigEndian.Uint32(header[0:4]))
+		if offset+walHeaderSize+length > info.Size() {
+			return offset, errTornRecord
+		}
+		data := make([]byte, length)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return offset, err
+		}
+		if crc32.Checksum(data, walTable) != binary.BigEndian.Uint32(header[4:8]) {
+			return offset, errTornRecord
+		}
+		entries, err := decodeRecord(data)
+		if err != nil {
+			return offset, errTornRecord
+		}
+		for _, entry := range entries {
+			fn(entry)
+		}
+		offset += walHeaderSize + length
+	}
+}
+
+func decodeRecord(data []byte) ([]ActionLogEntry, error) {
+	if len(data) > 0 && data[0] == '[' {
+		var entries []ActionLogEntry
+		err := json.Unmarshal(data, &entries)
+		return entries, err
+	}
+	var entry ActionLogEntry
+	err := json.Unmarshal(data, &entry)
+	return []ActionLogEntry{entry}, err
+}
+
+func syncDir(dir string) error {
+	file, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return file.Sync()
+}
@@ -0,0 +1,305 @@
+package redux_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/janmbaco/go-redux/src"
+	"github.com/janmbaco/go-redux/src/ioc/resolver"
+)
+
+func increment(seq uint64) redux.ActionLogEntry {
+	return redux.ActionLogEntry{Seq: seq, Selector: "counter", Action: "Increment", Payload: []byte("1")}
+}
+
+func appendEach(t *testing.T, wal redux.WAL, seqs ...uint64) {
+	t.Helper()
+	for _, seq := range seqs {
+		if err := wal.Append(increment(seq)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func checkSeqs(t *testing.T, wal redux.WAL, fromSeq uint64, want ...uint64) {
+	t.Helper()
+	entries, err := wal.Entries(fromSeq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("the entries from %v are %v, want the seqs %v", fromSeq, entries, want)
+	}
+	for i, entry := range entries {
+		if entry.Seq != want[i] {
+			t.Fatalf("the entries from %v are %v, want the seqs %v", fromSeq, entries, want)
+		}
+	}
+}
+
+func segmentsOf(t *testing.T, dir string) []string {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join(dir, "*.wal"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return paths
+}
+
+func TestWALRotatesAndReadsAcrossSegments(t *testing.T) {
+	dir := t.TempDir()
+	wal := redux.NewWAL(dir, redux.WALOptions{SegmentSize: 1, Sync: redux.SyncAlways})
+	appendEach(t, wal, 1, 2, 3)
+
+	if paths := segmentsOf(t, dir); len(paths) != 3 {
+		t.Fatalf("the segments are %v, want 3", paths)
+	}
+	checkSeqs(t, wal, 0, 1, 2, 3)
+	checkSeqs(t, wal, 2, 2, 3)
+	if err := wal.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	wal = redux.NewWAL(dir, redux.WALOptions{SegmentSize: 1, Sync: redux.SyncAlways})
+	defer wal.Close()
+	if seq, _ := wal.LastSeq(); seq != 3 {
+		t.Fatalf("the last seq is %v, want 3", seq)
+	}
+	appendEach(t, wal, 4)
+	checkSeqs(t, wal, 3, 3, 4)
+}
+
+func TestWALSyncPolicies(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		policy redux.SyncPolicy
+	}{
+		{name: "always", policy: redux.SyncAlways},
+		{name: "every", policy: redux.SyncEvery(time.Millisecond)},
+		{name: "never", policy: redux.SyncNever},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			wal := redux.NewWAL(dir, redux.WALOptions{SegmentSize: 1, Sync: test.policy})
+			appendEach(t, wal, 1, 2)
+			if err := wal.Sync(); err != nil {
+				t.Fatal(err)
+			}
+			if err := wal.Compact(1); err != nil {
+				t.Fatal(err)
+			}
+			if err := wal.Close(); err != nil {
+				t.Fatal(err)
+			}
+			if err := wal.Append(increment(3)); err == nil {
+				t.Fatal("a closed WAL accepted an entry")
+			}
+
+			wal = redux.NewWAL(dir, redux.WALOptions{Sync: test.policy})
+			defer wal.Close()
+			checkSeqs(t, wal, 0, 2)
+		})
+	}
+}
+
+func TestSyncEveryRejectsAnEmptyInterval(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("SyncEvery accepted an interval of zero")
+		}
+	}()
+	redux.SyncEvery(0)
+}
+
+func TestWALRejectsDecreasingSeqs(t *testing.T) {
+	wal := redux.NewWAL(t.TempDir(), redux.WALOptions{Sync: redux.SyncNever})
+	defer wal.Close()
+	appendEach(t, wal, 2)
+	if err := wal.Append(increment(2)); err == nil {
+		t.Fatal("the WAL accepted a repeated seq")
+	}
+	if err := wal.Append(increment(3), increment(3)); err == nil {
+		t.Fatal("the WAL accepted a batch with a repeated seq")
+	}
+	checkSeqs(t, wal, 0, 2)
+}
+
+func TestWALDiscardsTornBatches(t *testing.T) {
+	dir := t.TempDir()
+	wal := redux.NewWAL(dir, redux.WALOptions{Sync: redux.SyncAlways})
+	appendEach(t, wal, 1)
+	if err := wal.Append(increment(2), increment(3)); err != nil {
+		t.Fatal(err)
+	}
+	if err := wal.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	paths := segmentsOf(t, dir)
+	info, err := os.Stat(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(paths[0], info.Size()-10); err != nil {
+		t.Fatal(err)
+	}
+
+	wal = redux.NewWAL(dir, redux.WALOptions{Sync: redux.SyncAlways})
+	defer wal.Close()
+	checkSeqs(t, wal, 0, 1)
+	if seq, _ := wal.LastSeq(); seq != 1 {
+		t.Fatalf("the last seq is %v, want 1", seq)
+	}
+}
+
+func TestWALTruncatesATornTail(t *testing.T) {
+	dir := t.TempDir()
+	wal := redux.NewWAL(dir, redux.WALOptions{Sync: redux.SyncAlways})
+	appendEach(t, wal, 1, 2)
+	if err := wal.Close(); err != nil {
+		t.Fatal(err)
+	}
+	path := segmentsOf(t, dir)[0]
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Truncate(path, info.Size()-1); err != nil {
+		t.Fatal(err)
+	}
+
+	wal = redux.NewWAL(dir, redux.WALOptions{Sync: redux.SyncAlways})
+	defer wal.Close()
+	checkSeqs(t, wal, 0, 1)
+	appendEach(t, wal, 2)
+	checkSeqs(t, wal, 0, 1, 2)
+}
+
+func corruptTheLastRecord(t *testing.T, path string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[len(data)-2] ^= 0xFF
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWALDiscardsARecordWithAWrongChecksumAtTheTail(t *testing.T) {
+	dir := t.TempDir()
+	wal := redux.NewWAL(dir, redux.WALOptions{Sync: redux.SyncAlways})
+	appendEach(t, wal, 1, 2)
+	if err := wal.Close(); err != nil {
+		t.Fatal(err)
+	}
+	corruptTheLastRecord(t, segmentsOf(t, dir)[0])
+
+	wal = redux.NewWAL(dir, redux.WALOptions{Sync: redux.SyncAlways})
+	defer wal.Close()
+	checkSeqs(t, wal, 0, 1)
+	if seq, _ := wal.LastSeq(); seq != 1 {
+		t.Fatalf("the last seq is %v, want 1", seq)
+	}
+}
+
+func TestWALRejectsAWrongChecksumBeforeTheLastSegment(t *testing.T) {
+	dir := t.TempDir()
+	wal := redux.NewWAL(dir, redux.WALOptions{SegmentSize: 1, Sync: redux.SyncAlways})
+	appendEach(t, wal, 1, 2)
+	if err := wal.Close(); err != nil {
+		t.Fatal(err)
+	}
+	corruptTheLastRecord(t, segmentsOf(t, dir)[0])
+
+	err := catch(func() {
+		redux.NewWAL(dir, redux.WALOptions{SegmentSize: 1, Sync: redux.SyncAlways})
+	})
+	if err == nil || !strings.Contains(err.Error(), "is corrupted") {
+		t.Fatalf("the error is %v, want a corrupted segment", err)
+	}
+}
+
+func TestWALCompactRemovesTheCoveredSegments(t *testing.T) {
+	dir := t.TempDir()
+	wal := redux.NewWAL(dir, redux.WALOptions{SegmentSize: 1, Sync: redux.SyncAlways})
+	defer wal.Close()
+	appendEach(t, wal, 1, 2, 3)
+
+	if err := wal.Compact(1); err != nil {
+		t.Fatal(err)
+	}
+	checkSeqs(t, wal, 0, 2, 3)
+	if err := wal.Compact(10); err != nil {
+		t.Fatal(err)
+	}
+	checkSeqs(t, wal, 0, 3)
+	if paths := segmentsOf(t, dir); len(paths) != 1 {
+		t.Fatalf("the segments are %v, want only the last one", paths)
+	}
+	appendEach(t, wal, 4)
+	checkSeqs(t, wal, 0, 3, 4)
+}
+
+func newPersistentCounterStore(t *testing.T, persister redux.Persister) (redux.Store, *counterActions) {
+	t.Helper()
+	actions := &counterActions{}
+	builder := resolver.GetBusinessParamBuilder()
+	builder.SetInitialState(0)
+	builder.SetActions(actions)
+	builder.On(actions.Increment, func(state int, payload int) int {
+		return state + payload
+	})
+	builder.On(actions.Reset, func(state int) int {
+		return 0
+	})
+	builder.On(actions.Fail, func(state int) int {
+		panic("failure")
+	})
+	builder.SetPersistent(true)
+	builder.SetSelector("counter")
+	store := resolver.GetStore()
+	store.SetPersister(persister, 0)
+	store.AddReducer(builder.GetBusinessParam())
+	t.Cleanup(store.Shutdown)
+	return store, actions
+}
+
+func TestRecoverAppliesTheWALAfterTheSnapshot(t *testing.T) {
+	persister := redux.NewFilePersister(t.TempDir(), redux.NewJSONCodec())
+	dir := t.TempDir()
+	wal := redux.NewWAL(dir, redux.WALOptions{SegmentSize: 1, Sync: redux.SyncAlways})
+	store, actions := newPersistentCounterStore(t, persister)
+	store.SetActionLog(wal)
+	store.Dispatch(actions.Increment.With(1))
+	store.Dispatch(actions.Increment.With(2))
+	store.SetPersister(nil, 0)
+	store.Dispatch(actions.Increment.With(4))
+	store.Dispatch(actions.Increment.With(8))
+	store.SetActionLog(nil)
+	if err := wal.Compact(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := wal.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	wal = redux.NewWAL(dir, redux.WALOptions{SegmentSize: 1, Sync: redux.SyncAlways})
+	defer wal.Close()
+	store, actions = newPersistentCounterStore(t, persister)
+	if state := store.GetStateOf("counter"); state != 3 {
+		t.Fatalf("the state of the snapshot is %v, want 3", state)
+	}
+	store.Recover(wal)
+
+	if state := store.GetStateOf("counter"); state != 15 {
+		t.Fatalf("the recovered state is %v, want 15", state)
+	}
+	store.SetActionLog(wal)
+	store.Dispatch(actions.Increment.With(16))
+	checkSeqs(t, wal, 4, 4, 5)
+}
@@ -0,0 +1,177 @@
+package redux
+
+import (
+	"context"
+	"sync"
+
+	"github.com/janmbaco/go-infrastructure/errors/errorschecker"
+)
+
+type OverflowPolicy uint8
+
+const (
+	OverflowBlock OverflowPolicy = iota
+	OverflowDropOldest
+	OverflowDropNewest
+	OverflowCoalesce
+)
+
+type WatchOptions struct {
+	Buffer   int
+	Overflow OverflowPolicy
+}
+
+type watcher struct {
+	mutex     sync.Mutex
+	changes   chan Change
+	done      chan struct{}
+	closeOnce sync.Once
+	overflow  OverflowPolicy
+	closed    bool
+}
+
+func (s *store) Watch(ctx context.Context, selector string, options WatchOptions) <-chan Change {
+	defer s.errorDefer.TryThrowError(s.errorPipe)
+	errorschecker.CheckNilParameter(map[string]interface{}{"ctx": ctx})
+	checkSelector(selector)
+	if options.Buffer < 0 {
+		panic("The buffer can not be negative!")
+	}
+	if options.Buffer == 0 && options.Overflow != OverflowBlock {
+		options.Buffer = 1
+	}
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	s.checkActiveSlice(selector)
+
+	w := &watcher{changes: make(chan Change, options.Buffer), done: make(chan struct{}), overflow: options.Overflow}
+	s.watchMutex.Lock()
+	defer s.watchMutex.Unlock()
+	if s.ctx.Err() != nil {
+		w.close()
+		return w.changes
+	}
+	s.watchers[selector] = append(s.watchers[selector], w)
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.removeWatcher(selector, w)
+		case <-w.done:
+		}
+	}()
+	return w.changes
+}
+
+func (s *store) publishWatchers(changes []Change) {
+	s.watchMutex.Lock()
+	watchers := make(map[string][]*watcher, len(changes))
+	for _, change := range changes {
+		watchers[change.Selector] = s.watchers[change.Selector]
+	}
+	s.watchMutex.Unlock()
+
+	for _, change := range changes {
+		for _, w := range watchers[change.Selector] {
+			w.send(change)
+		}
+	}
+}
+
+func (s *store) removeWatcher(selector string, w *watcher) {
+	s.watchMutex.Lock()
+	watchers := s.watchers[selector]
+	for i, watcher := range watchers {
+		if watcher == w {
+			s.watchers[selector] = append(watchers[:i:i], watchers[i+1:]...)
+			break
+		}
+	}
+	if len(s.watchers[selector]) == 0 {
+		delete(s.watchers, selector)
+	}
+	s.watchMutex.Unlock()
+	w.close()
+}
+
+func (s *store) closeWatchers(selector string) {
+	s.watchMutex.Lock()
+	watchers := s.watchers[selector]
+	delete(s.watchers, selector)
+	s.watchMutex.Unlock()
+	for _, w := range watchers {
+		w.close()
+	}
+}
+
+func (s *store) closeAllWatchers() {
+	s.watchMutex.Lock()
+	watchers := s.watchers
+	s.watchers = make(map[string][]*watcher)
+	s.watchMutex.Unlock()
+	for _, selectorWatchers := range watchers {
+		for _, w := range selectorWatchers {
+			w.close()
+		}
+	}
+}
+
+func (w *watcher) send(change Change) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.closed {
+		return
+	}
+	switch w.overflow {
+	case OverflowDropNewest:
+		select {
+		case w.changes <- change:
+		default:
+		}
+	case OverflowDropOldest:
+		for {
+			select {
+			case w.changes <- change:
+				return
+			default:
+			}
+			select {
+			case <-w.changes:
+			default:
+			}
+		}
+	case OverflowCoalesce:
+		select {
+		case w.changes <- change:
+			return
+		default:
+		}
+		oldest := true
+		for drained := false; !drained; {
+			select {
+			case pending := <-w.changes:
+				if oldest {
+					change.Prev = pending.Prev
+					oldest = false
+				}
+			default:
+				drained = true
+			}
+		}
+		w.changes <- change
+	default:
+		select {
+		case w.changes <- change:
+		case <-w.done:
+		}
+	}
+}
+
+func (w *watcher) close() {
+	w.closeOnce.Do(func() {
+		close(w.done)
+		w.mutex.Lock()
+		defer w.mutex.Unlock()
+		w.closed = true
+		close(w.changes)
+	})
+}
@@ -0,0 +1,154 @@
+package redux_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/janmbaco/go-redux/src"
+	"github.com/janmbaco/go-redux/src/jsonpatch"
+)
+
+func TestSubscriptionsToARemovedSliceFail(t *testing.T) {
+	store, actions := newCounterStore(t)
+	store.Dispatch(actions.Increment.With(1))
+	store.RemoveReducer("counter")
+
+	if state := store.GetStateOf("counter"); state != 1 {
+		t.Fatalf("the state of the removed slice is %v, want 1", state)
+	}
+	onState := func(interface{}) {}
+	onChange := func(redux.Change) {}
+	onPatch := func(redux.Change, jsonpatch.Patch) {}
+	for name, subscribe := range map[string]func(){
+		"SubscribeTo":         func() { store.SubscribeTo("counter", &onState) },
+		"SubscribeToChanges":  func() { store.SubscribeToChanges("counter", &onChange) },
+		"SubscribeToPatches":  func() { store.SubscribeToPatches("counter", &onPatch) },
+		"SubscribeToSelector": func() { store.SubscribeToSelector(redux.Select("counter"), &onState) },
+		"Watch":               func() { store.Watch(context.Background(), "counter", redux.WatchOptions{}) },
+	} {
+		if err := catch(subscribe); !errors.Is(err, redux.RemovedSliceError) {
+			t.Errorf("%v returned %v, want a RemovedSliceError", name, err)
+		}
+	}
+}
+
+func receiveChanges(changes <-chan redux.Change) []redux.Change {
+	received := make([]redux.Change, 0)
+	for {
+		select {
+		case change := <-changes:
+			received = append(received, change)
+		default:
+			return received
+		}
+	}
+}
+
+func TestWatchOverflowPolicies(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		overflow redux.OverflowPolicy
+		want     [][2]int
+	}{
+		{name: "drop oldest", overflow: redux.OverflowDropOldest, want: [][2]int{{2, 3}, {3, 4}}},
+		{name: "drop newest", overflow: redux.OverflowDropNewest, want: [][2]int{{0, 1}, {1, 2}}},
+		{name: "coalesce", overflow: redux.OverflowCoalesce, want: [][2]int{{0, 3}, {3, 4}}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			store, actions := newCounterStore(t)
+			changes := store.Watch(context.Background(), "counter", redux.WatchOptions{Buffer: 2, Overflow: test.overflow})
+			for i := 0; i < 4; i++ {
+				store.Dispatch(actions.Increment.With(1))
+			}
+
+			received := receiveChanges(changes)
+			if len(received) != len(test.want) {
+				t.Fatalf("%v changes were received, want %v", len(received), len(test.want))
+			}
+			for i, change := range received {
+				if change.Prev != test.want[i][0] || change.Next != test.want[i][1] {
+					t.Fatalf("the change %v is %v -> %v, want %v -> %v", i, change.Prev, change.Next, test.want[i][0], test.want[i][1])
+				}
+			}
+		})
+	}
+}
+
+func TestWatchBlocksTheDispatchWhenTheBufferIsFull(t *testing.T) {
+	store, actions := newCounterStore(t)
+	changes := store.Watch(context.Background(), "counter", redux.WatchOptions{Buffer: 1})
+	store.Dispatch(actions.Increment.With(1))
+
+	dispatched := make(chan struct{})
+	go func() {
+		store.Dispatch(actions.Increment.With(1))
+		close(dispatched)
+	}()
+	select {
+	case <-dispatched:
+		t.Fatal("the dispatch did not wait for the full buffer")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if change := <-changes; change.Next != 1 {
+		t.Fatalf("the first change is %v, want 1", change.Next)
+	}
+	select {
+	case <-dispatched:
+	case <-time.After(time.Second):
+		t.Fatal("the dispatch is still blocked after receiving a change")
+	}
+	if change := <-changes; change.Next != 2 {
+		t.Fatalf("the second change is %v, want 2", change.Next)
+	}
+}
+
+func TestWatchIsClosedWhenTheContextIsCancelled(t *testing.T) {
+	store, _ := newCounterStore(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	changes := store.Watch(ctx, "counter", redux.WatchOptions{})
+	cancel()
+	select {
+	case _, open := <-changes:
+		if open {
+			t.Fatal("a change was received after cancelling the context")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the channel has not been closed")
+	}
+}
+
+func TestShutdownClosesEveryWatch(t *testing.T) {
+	store, _ := newCounterStore(t)
+	addLabel(store)
+	watched := []<-chan redux.Change{
+		store.Watch(context.Background(), "counter", redux.WatchOptions{}),
+		store.Watch(context.Background(), "counter", redux.WatchOptions{Buffer: 4, Overflow: redux.OverflowCoalesce}),
+		store.Watch(context.Background(), "label", redux.WatchOptions{}),
+	}
+	store.Shutdown()
+	watched = append(watched, store.Watch(context.Background(), "label", redux.WatchOptions{}))
+
+	for i, changes := range watched {
+		select {
+		case _, open := <-changes:
+			if open {
+				t.Fatalf("the watch %v received a change after the shutdown", i)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("the watch %v has not been closed", i)
+		}
+	}
+}
+
+func catch(fn func()) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = recovered.(error)
+		}
+	}()
+	fn()
+	return nil
+}